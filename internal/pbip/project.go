@@ -0,0 +1,193 @@
+package pbip
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Project represents a Power BI Project folder containing a semantic model and / or a report definition
+type Project struct {
+	SemanticModelFolder string
+	ReportFolder        string
+}
+
+// Part represents a single file of a semantic model or report definition
+type Part struct {
+	Path    string
+	Content []byte
+}
+
+// files that only apply to Power BI Desktop or git integration and are not part of the item definition
+var excludedFiles = map[string]bool{
+	"item.metadata.json": true,
+	"item.config.json":   true,
+	".platform":          true,
+}
+
+// OpenProject finds the *.SemanticModel and *.Report folders within a Power BI Project folder
+func OpenProject(folder string) (*Project, error) {
+	entries, err := ioutil.ReadDir(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	project := Project{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		var target *string
+		switch {
+		case strings.HasSuffix(entry.Name(), ".SemanticModel") || strings.HasSuffix(entry.Name(), ".Dataset"):
+			target = &project.SemanticModelFolder
+		case strings.HasSuffix(entry.Name(), ".Report"):
+			target = &project.ReportFolder
+		default:
+			continue
+		}
+
+		if *target != "" {
+			return nil, fmt.Errorf("Project folder '%s' contains more than one '%s' folder", folder, filepath.Ext(entry.Name()))
+		}
+		*target = filepath.Join(folder, entry.Name())
+	}
+
+	if project.SemanticModelFolder == "" && project.ReportFolder == "" {
+		return nil, fmt.Errorf("Project folder '%s' does not contain a *.SemanticModel or *.Report folder", folder)
+	}
+	return &project, nil
+}
+
+// SemanticModelParts reads the files of the semantic model definition, either TMDL files or model.bim
+func (project *Project) SemanticModelParts() ([]Part, error) {
+	if project.SemanticModelFolder == "" {
+		return nil, nil
+	}
+	return readParts(project.SemanticModelFolder)
+}
+
+// ReportParts reads the files of the report definition. A report that references the semantic model of the
+// project by path is rebound to the given semantic model ID, as the service only accepts references by connection
+func (project *Project) ReportParts(semanticModelID string) ([]Part, error) {
+	if project.ReportFolder == "" {
+		return nil, nil
+	}
+
+	parts, err := readParts(project.ReportFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, part := range parts {
+		if part.Path != "definition.pbir" {
+			continue
+		}
+
+		content, err := rewriteDatasetReference(part.Content, semanticModelID)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to rebind '%s': %w", filepath.Join(project.ReportFolder, part.Path), err)
+		}
+		parts[i].Content = content
+	}
+	return parts, nil
+}
+
+// ContentHash calculates a MD5 hash over the paths and contents of all definition files within the project
+func (project *Project) ContentHash() (string, error) {
+	hash := md5.New()
+
+	for _, folder := range []string{project.SemanticModelFolder, project.ReportFolder} {
+		if folder == "" {
+			continue
+		}
+
+		parts, err := readParts(folder)
+		if err != nil {
+			return "", err
+		}
+		for _, part := range parts {
+			fmt.Fprintf(hash, "%s/%s\n%d\n", filepath.Base(folder), part.Path, len(part.Content))
+			hash.Write(part.Content)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func readParts(folder string) ([]Part, error) {
+	parts := make([]Part, 0)
+
+	err := filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(folder, path)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+
+		// the .pbi folder holds local settings and caches of Power BI Desktop
+		if info.IsDir() {
+			if relativePath == ".pbi" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if excludedFiles[relativePath] {
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, Part{
+			Path:    relativePath,
+			Content: content,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(parts, func(i, j int) bool {
+		return parts[i].Path < parts[j].Path
+	})
+	return parts, nil
+}
+
+func rewriteDatasetReference(content []byte, semanticModelID string) ([]byte, error) {
+	var pbir map[string]interface{}
+	err := json.Unmarshal(content, &pbir)
+	if err != nil {
+		return nil, err
+	}
+
+	datasetReference, _ := pbir["datasetReference"].(map[string]interface{})
+	if _, ok := datasetReference["byPath"]; !ok {
+		return content, nil
+	}
+
+	if semanticModelID == "" {
+		return nil, fmt.Errorf("report references a semantic model by path, but the project does not contain a semantic model")
+	}
+
+	pbir["version"] = "4.0"
+	pbir["datasetReference"] = map[string]interface{}{
+		"byConnection": map[string]interface{}{
+			"connectionString": fmt.Sprintf("semanticmodelid=%s", semanticModelID),
+		},
+	}
+	return json.MarshalIndent(pbir, "", "  ")
+}
@@ -0,0 +1,303 @@
+package pbip
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testProjectFolder = "../powerbi/resource_pbip_test_sample"
+
+// copyTestProject copies the sample project into a temporary folder that the test can modify
+func copyTestProject(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "pbip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = filepath.Walk(testProjectFolder, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(testProjectFolder, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, relativePath)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(target, content, 0644)
+	})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func writeTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func partPaths(parts []Part) []string {
+	var paths []string
+	for _, part := range parts {
+		paths = append(paths, part.Path)
+	}
+	return paths
+}
+
+func TestOpenProject(t *testing.T) {
+	project, err := OpenProject(testProjectFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if project.SemanticModelFolder != filepath.Join(testProjectFolder, "Sample.SemanticModel") {
+		t.Fatalf("Expected the semantic model folder to be found, found '%s'", project.SemanticModelFolder)
+	}
+	if project.ReportFolder != filepath.Join(testProjectFolder, "Sample.Report") {
+		t.Fatalf("Expected the report folder to be found, found '%s'", project.ReportFolder)
+	}
+}
+
+func TestOpenProject_datasetFolder(t *testing.T) {
+	dir := copyTestProject(t)
+	defer os.RemoveAll(dir)
+
+	// older projects name the semantic model folder *.Dataset
+	err := os.Rename(filepath.Join(dir, "Sample.SemanticModel"), filepath.Join(dir, "Sample.Dataset"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := OpenProject(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if project.SemanticModelFolder != filepath.Join(dir, "Sample.Dataset") {
+		t.Fatalf("Expected the dataset folder to be used as the semantic model, found '%s'", project.SemanticModelFolder)
+	}
+}
+
+func TestOpenProject_duplicateFolders(t *testing.T) {
+	dir := copyTestProject(t)
+	defer os.RemoveAll(dir)
+
+	err := os.Mkdir(filepath.Join(dir, "Other.Report"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = OpenProject(dir)
+	if err == nil || !strings.Contains(err.Error(), "contains more than one '.Report' folder") {
+		t.Fatalf("Expected an error for the duplicate report folder, found %v", err)
+	}
+}
+
+func TestOpenProject_missingFolders(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pbip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeTestFile(t, filepath.Join(dir, "Sample.pbip"), "{}")
+
+	_, err = OpenProject(dir)
+	if err == nil || !strings.Contains(err.Error(), "does not contain a *.SemanticModel or *.Report folder") {
+		t.Fatalf("Expected an error for the missing folders, found %v", err)
+	}
+}
+
+func TestSemanticModelParts(t *testing.T) {
+	project, err := OpenProject(testProjectFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts, err := project.SemanticModelParts()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// parts are sorted by path, which uses forward slashes on all platforms
+	expected := []string{
+		"definition.pbism",
+		"definition/database.tmdl",
+		"definition/model.tmdl",
+		"definition/tables/Names.tmdl",
+	}
+	if strings.Join(partPaths(parts), ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected parts %v, found %v", expected, partPaths(parts))
+	}
+}
+
+func TestContentHash_excludesLocalFiles(t *testing.T) {
+	dir := copyTestProject(t)
+	defer os.RemoveAll(dir)
+
+	project, err := OpenProject(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := project.ContentHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// files written by Power BI Desktop and git integration are not part of the definition
+	writeTestFile(t, filepath.Join(project.SemanticModelFolder, ".pbi", "localSettings.json"), "{}")
+	writeTestFile(t, filepath.Join(project.SemanticModelFolder, ".pbi", "cache.abf"), "cache")
+	writeTestFile(t, filepath.Join(project.SemanticModelFolder, "item.metadata.json"), "{}")
+	writeTestFile(t, filepath.Join(project.ReportFolder, "item.config.json"), "{}")
+	writeTestFile(t, filepath.Join(project.ReportFolder, ".platform"), "{}")
+
+	parts, err := project.SemanticModelParts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range parts {
+		if strings.HasPrefix(part.Path, ".pbi/") || strings.HasPrefix(part.Path, "item.") {
+			t.Fatalf("Expected '%s' to be excluded from the semantic model parts", part.Path)
+		}
+	}
+	reportParts, err := project.ReportParts("00000000-0000-0000-0000-000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(partPaths(reportParts), ",") != "definition.pbir,report.json" {
+		t.Fatalf("Expected only the report definition parts, found %v", partPaths(reportParts))
+	}
+
+	hashWithLocalFiles, err := project.ContentHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hashWithLocalFiles != hash {
+		t.Fatalf("Expected the content hash to ignore local files, found %s was %s", hashWithLocalFiles, hash)
+	}
+}
+
+func TestContentHash(t *testing.T) {
+	dir := copyTestProject(t)
+	defer os.RemoveAll(dir)
+
+	project, err := OpenProject(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := project.ContentHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the hash only depends on the content, not on where the project is
+	sampleProject, err := OpenProject(testProjectFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sampleHash, err := sampleProject.ContentHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != sampleHash {
+		t.Fatalf("Expected copies of a project to have the same content hash, found %s and %s", hash, sampleHash)
+	}
+
+	writeTestFile(t, filepath.Join(project.SemanticModelFolder, "definition", "tables", "Other.tmdl"), "table Other")
+	changedHash, err := project.ContentHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changedHash == hash {
+		t.Fatal("Expected the content hash to change when a definition file is added")
+	}
+}
+
+func TestReportParts_rebindsDatasetReference(t *testing.T) {
+	project, err := OpenProject(testProjectFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts, err := project.ReportParts("11111111-1111-1111-1111-111111111111")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, part := range parts {
+		if part.Path != "definition.pbir" {
+			continue
+		}
+
+		var pbir struct {
+			DatasetReference map[string]struct {
+				ConnectionString string `json:"connectionString"`
+			} `json:"datasetReference"`
+		}
+		err := json.Unmarshal(part.Content, &pbir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := pbir.DatasetReference["byPath"]; ok {
+			t.Fatal("Expected the reference by path to be replaced")
+		}
+		if pbir.DatasetReference["byConnection"].ConnectionString != "semanticmodelid=11111111-1111-1111-1111-111111111111" {
+			t.Fatalf("Expected the report to reference the semantic model by connection, found %s", string(part.Content))
+		}
+		return
+	}
+	t.Fatal("Expected the report parts to contain definition.pbir")
+}
+
+func TestReportParts_referenceByPathWithoutSemanticModel(t *testing.T) {
+	project, err := OpenProject(testProjectFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = project.ReportParts("")
+	if err == nil || !strings.Contains(err.Error(), "the project does not contain a semantic model") {
+		t.Fatalf("Expected an error for a reference by path without a semantic model, found %v", err)
+	}
+}
+
+func TestReportParts_keepsReferenceByConnection(t *testing.T) {
+	dir := copyTestProject(t)
+	defer os.RemoveAll(dir)
+
+	pbir := `{"version":"4.0","datasetReference":{"byConnection":{"connectionString":"semanticmodelid=22222222-2222-2222-2222-222222222222"}}}`
+	writeTestFile(t, filepath.Join(dir, "Sample.Report", "definition.pbir"), pbir)
+
+	project, err := OpenProject(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts, err := project.ReportParts("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range parts {
+		if part.Path == "definition.pbir" && string(part.Content) != pbir {
+			t.Fatalf("Expected a reference by connection to be kept, found %s", string(part.Content))
+		}
+	}
+}
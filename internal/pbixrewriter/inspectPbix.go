@@ -0,0 +1,367 @@
+package pbixrewriter
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PbixInfo represents the metadata read from a PBIX file
+type PbixInfo struct {
+	Version        string
+	HasDataModel   bool
+	Connections    []PbixConnection
+	Pages          []PbixPage
+	ActivePageName string
+	Tables         []PbixTable
+	Parameters     []PbixParameter
+	Expressions    []PbixExpression
+}
+
+// PbixConnection represents a connection of a PBIX, such as the live connection of a thin report
+type PbixConnection struct {
+	Name             string
+	ConnectionString string
+	ConnectionType   string
+	DatasetID        string
+}
+
+// PbixPage represents a page of the report within a PBIX
+type PbixPage struct {
+	Name        string
+	DisplayName string
+	Ordinal     int
+	Hidden      bool
+	Visuals     []PbixVisual
+}
+
+// PbixVisual represents a visual on a page of the report within a PBIX
+type PbixVisual struct {
+	Name       string
+	VisualType string
+}
+
+// PbixTable represents a table of the data model within a PBIX
+type PbixTable struct {
+	Name         string
+	MExpressions []string
+}
+
+// PbixParameter represents a parameter of the data model within a PBIX
+type PbixParameter struct {
+	Name       string
+	Type       string
+	IsRequired bool
+	Value      string
+}
+
+// PbixExpression represents a shared expression of the data model within a PBIX, such as an M query
+type PbixExpression struct {
+	Name       string
+	Kind       string
+	Expression string
+}
+
+type pbixConnectionsJSON struct {
+	Connections []struct {
+		Name                 string
+		ConnectionString     string
+		ConnectionType       string
+		PbiModelDatabaseName string
+	}
+}
+
+type pbixLayoutJSON struct {
+	Config   string `json:"config"`
+	Sections []struct {
+		Name             string `json:"name"`
+		DisplayName      string `json:"displayName"`
+		Ordinal          int    `json:"ordinal"`
+		Config           string `json:"config"`
+		VisualContainers []struct {
+			Config string `json:"config"`
+		} `json:"visualContainers"`
+	} `json:"sections"`
+}
+
+type pbixLayoutConfigJSON struct {
+	ActiveSectionIndex int `json:"activeSectionIndex"`
+}
+
+type pbixSectionConfigJSON struct {
+	Visibility int `json:"visibility"`
+}
+
+type pbixVisualConfigJSON struct {
+	Name         string `json:"name"`
+	SingleVisual *struct {
+		VisualType string `json:"visualType"`
+	} `json:"singleVisual"`
+	SingleVisualGroup *struct {
+		DisplayName string `json:"displayName"`
+	} `json:"singleVisualGroup"`
+}
+
+type pbixDataModelSchemaJSON struct {
+	Model struct {
+		Tables []struct {
+			Name       string `json:"name"`
+			Partitions []struct {
+				Source struct {
+					Type       string          `json:"type"`
+					Expression json.RawMessage `json:"expression"`
+				} `json:"source"`
+			} `json:"partitions"`
+		} `json:"tables"`
+		Expressions []struct {
+			Name       string          `json:"name"`
+			Kind       string          `json:"kind"`
+			Expression json.RawMessage `json:"expression"`
+		} `json:"expressions"`
+	} `json:"model"`
+}
+
+// hidden pages are marked with this visibility in the section config
+const pbixPageHiddenVisibility = 1
+
+var parameterMetaRegex = regexp.MustCompile(`(?s)^(.*)\s+meta\s+\[(.*)\]\s*$`)
+var parameterMetaIsParameterRegex = regexp.MustCompile(`\bIsParameterQuery\s*=\s*true\b`)
+var parameterMetaTypeRegex = regexp.MustCompile(`Type\s*=\s*"([^"]*)"`)
+var parameterMetaIsRequiredRegex = regexp.MustCompile(`IsParameterQueryRequired\s*=\s*(true|false)`)
+
+// InspectPbixFile reads the metadata of a PBIX file without modifying it
+func InspectPbixFile(inputPbixFile string) (*PbixInfo, error) {
+	zipReader, err := zip.OpenReader(inputPbixFile)
+	if err != nil {
+		return nil, err
+	}
+	defer zipReader.Close()
+
+	return InspectPbix(&zipReader.Reader)
+}
+
+// InspectPbix reads the metadata of a PBIX archive. Entries that are not present are left empty
+func InspectPbix(input *zip.Reader) (*PbixInfo, error) {
+	info := PbixInfo{}
+
+	for _, inputItem := range input.File {
+		var err error
+		switch inputItem.Name {
+		case "Version":
+			err = inspectPbixVersion(inputItem, &info)
+		case "Connections":
+			err = inspectPbixConnections(inputItem, &info)
+		case "Report/Layout":
+			err = inspectPbixLayout(inputItem, &info)
+		case "DataModel":
+			info.HasDataModel = true
+		case "DataModelSchema":
+			info.HasDataModel = true
+			err = inspectPbixDataModelSchema(inputItem, &info)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Unable to inspect '%s': %w", inputItem.Name, err)
+		}
+	}
+
+	return &info, nil
+}
+
+func readPbixItem(inputItem *zip.File) ([]byte, error) {
+	reader, err := inputItem.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
+
+func readPbixUTF16Item(inputItem *zip.File) (string, error) {
+	content, err := readPbixItem(inputItem)
+	if err != nil {
+		return "", err
+	}
+	return decodeUTF16LE(content)
+}
+
+func inspectPbixVersion(inputItem *zip.File, info *PbixInfo) error {
+	version, err := readPbixUTF16Item(inputItem)
+	if err != nil {
+		return err
+	}
+	info.Version = strings.TrimSpace(version)
+	return nil
+}
+
+func inspectPbixConnections(inputItem *zip.File, info *PbixInfo) error {
+	content, err := readPbixItem(inputItem)
+	if err != nil {
+		return err
+	}
+
+	var connections pbixConnectionsJSON
+	err = json.Unmarshal(content, &connections)
+	if err != nil {
+		return err
+	}
+
+	for _, connection := range connections.Connections {
+		info.Connections = append(info.Connections, PbixConnection{
+			Name:             connection.Name,
+			ConnectionString: connection.ConnectionString,
+			ConnectionType:   connection.ConnectionType,
+			DatasetID:        connection.PbiModelDatabaseName,
+		})
+	}
+	return nil
+}
+
+func inspectPbixLayout(inputItem *zip.File, info *PbixInfo) error {
+	content, err := readPbixUTF16Item(inputItem)
+	if err != nil {
+		return err
+	}
+
+	var layout pbixLayoutJSON
+	err = json.Unmarshal([]byte(content), &layout)
+	if err != nil {
+		return err
+	}
+
+	// the active section index is a position in the sections array, which is not necessarily in display order
+	activeSectionIndex := 0
+	if layout.Config != "" {
+		var layoutConfig pbixLayoutConfigJSON
+		err = json.Unmarshal([]byte(layout.Config), &layoutConfig)
+		if err != nil {
+			return err
+		}
+		activeSectionIndex = layoutConfig.ActiveSectionIndex
+	}
+	if activeSectionIndex >= 0 && activeSectionIndex < len(layout.Sections) {
+		info.ActivePageName = layout.Sections[activeSectionIndex].Name
+	}
+
+	for _, section := range layout.Sections {
+		page := PbixPage{
+			Name:        section.Name,
+			DisplayName: section.DisplayName,
+			Ordinal:     section.Ordinal,
+		}
+
+		if section.Config != "" {
+			var sectionConfig pbixSectionConfigJSON
+			err = json.Unmarshal([]byte(section.Config), &sectionConfig)
+			if err != nil {
+				return err
+			}
+			page.Hidden = sectionConfig.Visibility == pbixPageHiddenVisibility
+		}
+
+		for _, visualContainer := range section.VisualContainers {
+			var visualConfig pbixVisualConfigJSON
+			err = json.Unmarshal([]byte(visualContainer.Config), &visualConfig)
+			if err != nil {
+				return err
+			}
+
+			visual := PbixVisual{
+				Name: visualConfig.Name,
+			}
+			if visualConfig.SingleVisual != nil {
+				visual.VisualType = visualConfig.SingleVisual.VisualType
+			} else if visualConfig.SingleVisualGroup != nil {
+				visual.VisualType = "group"
+			}
+			page.Visuals = append(page.Visuals, visual)
+		}
+
+		info.Pages = append(info.Pages, page)
+	}
+
+	sort.SliceStable(info.Pages, func(i, j int) bool {
+		return info.Pages[i].Ordinal < info.Pages[j].Ordinal
+	})
+	return nil
+}
+
+func inspectPbixDataModelSchema(inputItem *zip.File, info *PbixInfo) error {
+	content, err := readPbixUTF16Item(inputItem)
+	if err != nil {
+		return err
+	}
+
+	var dataModelSchema pbixDataModelSchemaJSON
+	err = json.Unmarshal([]byte(content), &dataModelSchema)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range dataModelSchema.Model.Tables {
+		pbixTable := PbixTable{
+			Name: table.Name,
+		}
+		for _, partition := range table.Partitions {
+			if partition.Source.Type != "m" {
+				continue
+			}
+			expression, err := decodeDataModelExpression(partition.Source.Expression)
+			if err != nil {
+				return err
+			}
+			pbixTable.MExpressions = append(pbixTable.MExpressions, expression)
+		}
+		info.Tables = append(info.Tables, pbixTable)
+	}
+
+	for _, expression := range dataModelSchema.Model.Expressions {
+		expressionText, err := decodeDataModelExpression(expression.Expression)
+		if err != nil {
+			return err
+		}
+
+		info.Expressions = append(info.Expressions, PbixExpression{
+			Name:       expression.Name,
+			Kind:       expression.Kind,
+			Expression: expressionText,
+		})
+
+		// parameters are M expressions annotated with IsParameterQuery
+		if match := parameterMetaRegex.FindStringSubmatch(expressionText); match != nil && parameterMetaIsParameterRegex.MatchString(match[2]) {
+			parameter := PbixParameter{
+				Name:  expression.Name,
+				Value: strings.Trim(strings.TrimSpace(match[1]), `"`),
+			}
+			if typeMatch := parameterMetaTypeRegex.FindStringSubmatch(match[2]); typeMatch != nil {
+				parameter.Type = typeMatch[1]
+			}
+			if isRequiredMatch := parameterMetaIsRequiredRegex.FindStringSubmatch(match[2]); isRequiredMatch != nil {
+				parameter.IsRequired = isRequiredMatch[1] == "true"
+			}
+			info.Parameters = append(info.Parameters, parameter)
+		}
+	}
+
+	return nil
+}
+
+// decodeDataModelExpression decodes an expression, which the data model stores either as a string or as a list of lines
+func decodeDataModelExpression(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 {
+		return "", nil
+	}
+
+	var lines []string
+	if err := json.Unmarshal(raw, &lines); err == nil {
+		return strings.Join(lines, "\n"), nil
+	}
+
+	var expression string
+	err := json.Unmarshal(raw, &expression)
+	return expression, err
+}
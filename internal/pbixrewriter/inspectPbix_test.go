@@ -0,0 +1,173 @@
+package pbixrewriter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type testPbixEntry struct {
+	name    string
+	content []byte
+}
+
+// newTestPbixContent builds the content of a PBIX containing the entries in order
+func newTestPbixContent(t *testing.T, entries ...testPbixEntry) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, entry := range entries {
+		entryWriter, err := writer.Create(entry.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = entryWriter.Write(entry.content)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := writer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+// newTestPbix builds an in memory PBIX containing the entries in order
+func newTestPbix(t *testing.T, entries ...testPbixEntry) *zip.Reader {
+	t.Helper()
+
+	content := newTestPbixContent(t, entries...)
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reader
+}
+
+// rewriteTestPbix rewrites the PBIX in memory and returns the rewritten PBIX
+func rewriteTestPbix(t *testing.T, input *zip.Reader, pipelineFuncs ...PipelineFunc) (*zip.Reader, error) {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	err := RewritePbix(input, writer, pipelineFuncs)
+	if err != nil {
+		return nil, err
+	}
+	err = writer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reader, nil
+}
+
+// testLayout has sections stored in a different order to their display order
+const testLayout = `{
+	"config": "{\"activeSectionIndex\":0}",
+	"sections": [
+		{"name": "second", "displayName": "Second", "ordinal": 1, "config": "{}", "visualContainers": []},
+		{"name": "first", "displayName": "First", "ordinal": 0, "config": "{}", "visualContainers": []}
+	]
+}`
+
+func TestInspectPbix_activePageInSectionsOrder(t *testing.T) {
+	input := newTestPbix(t, testPbixEntry{"Report/Layout", encodeUTF16LE(testLayout)})
+
+	info, err := InspectPbix(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(info.Pages) != 2 || info.Pages[0].Name != "first" || info.Pages[1].Name != "second" {
+		t.Fatalf("Expected pages in display order, found %+v", info.Pages)
+	}
+	if info.ActivePageName != "second" {
+		t.Fatalf("Expected active page 'second', found '%s'", info.ActivePageName)
+	}
+}
+
+func TestInspectPbix_activePageSetByRewrite(t *testing.T) {
+	input := newTestPbix(t, testPbixEntry{"Report/Layout", encodeUTF16LE(testLayout)})
+
+	for _, page := range []string{"first", "second", "First", "Second"} {
+		output, err := rewriteTestPbix(t, input, SetActivePagePipelineFunc(page))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		info, err := InspectPbix(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		activePageFound := false
+		for _, inspectedPage := range info.Pages {
+			if inspectedPage.Name == info.ActivePageName && (inspectedPage.Name == page || inspectedPage.DisplayName == page) {
+				activePageFound = true
+			}
+		}
+		if !activePageFound {
+			t.Fatalf("Expected active page '%s' after rewriting, found '%s'", page, info.ActivePageName)
+		}
+	}
+}
+
+func TestInspectPbix_dataModel(t *testing.T) {
+	thinReport := newTestPbix(t, testPbixEntry{"Report/Layout", encodeUTF16LE(testLayout)})
+	info, err := InspectPbix(thinReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.HasDataModel {
+		t.Fatal("Expected a PBIX without a data model")
+	}
+
+	withDataModel := newTestPbix(t, testPbixEntry{"Report/Layout", encodeUTF16LE(testLayout)}, testPbixEntry{"DataModel", []byte{0}})
+	info, err = InspectPbix(withDataModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.HasDataModel {
+		t.Fatal("Expected a PBIX with a data model")
+	}
+}
+
+func TestInspectPbix_parameters(t *testing.T) {
+	dataModelSchema, err := json.Marshal(map[string]interface{}{
+		"model": map[string]interface{}{
+			"expressions": []map[string]interface{}{
+				{"name": "Compact", "kind": "m", "expression": `"a" meta [IsParameterQuery=true, Type="Text", IsParameterQueryRequired=true]`},
+				{"name": "Spaced", "kind": "m", "expression": `"b" meta [IsParameterQuery = true, Type = "Text", IsParameterQueryRequired = false]`},
+				{"name": "Lines", "kind": "m", "expression": []string{`1 meta [`, `	IsParameterQuery =true,`, `	Type="Number"`, `]`}},
+				{"name": "NotParameter", "kind": "m", "expression": `"c" meta [IsParameterQuery = false, IsParameterQueryRequired = true]`},
+				{"name": "Query", "kind": "m", "expression": `let Source = 1 in Source`},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := InspectPbix(newTestPbix(t, testPbixEntry{"DataModelSchema", encodeUTF16LE(string(dataModelSchema))}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []PbixParameter{
+		{Name: "Compact", Type: "Text", IsRequired: true, Value: "a"},
+		{Name: "Spaced", Type: "Text", IsRequired: false, Value: "b"},
+		{Name: "Lines", Type: "Number", IsRequired: false, Value: "1"},
+	}
+	if !reflect.DeepEqual(info.Parameters, expected) {
+		t.Fatalf("Expected parameters %+v, found %+v", expected, info.Parameters)
+	}
+}
@@ -0,0 +1,224 @@
+package pbixrewriter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+)
+
+// PageVisibility defines whether a page of the report is hidden. The page is matched by its name or display name
+type PageVisibility struct {
+	Page   string
+	Hidden bool
+}
+
+// SetPageVisibilityPipelineFunc hides or shows pages of the report within a PBIX
+func SetPageVisibilityPipelineFunc(pageVisibilities []PageVisibility) PipelineFunc {
+	return rewriteLayoutPipelineFunc(func(layout map[string]interface{}) error {
+		sections, err := layoutSections(layout)
+		if err != nil {
+			return err
+		}
+
+		for _, pageVisibility := range pageVisibilities {
+			index := findLayoutSection(sections, pageVisibility.Page)
+			if index < 0 {
+				return fmt.Errorf("Page '%s' does not exist in the report", pageVisibility.Page)
+			}
+
+			section := sections[index].(map[string]interface{})
+			err := rewriteJSONStringField(section, "config", func(sectionConfig map[string]interface{}) error {
+				if pageVisibility.Hidden {
+					sectionConfig["visibility"] = pbixPageHiddenVisibility
+				} else {
+					delete(sectionConfig, "visibility")
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SetActivePagePipelineFunc sets the page the report opens on. The page is matched by its name or display name
+func SetActivePagePipelineFunc(page string) PipelineFunc {
+	return rewriteLayoutPipelineFunc(func(layout map[string]interface{}) error {
+		sections, err := layoutSections(layout)
+		if err != nil {
+			return err
+		}
+
+		index := findLayoutSection(sections, page)
+		if index < 0 {
+			return fmt.Errorf("Page '%s' does not exist in the report", page)
+		}
+
+		return rewriteJSONStringField(layout, "config", func(layoutConfig map[string]interface{}) error {
+			layoutConfig["activeSectionIndex"] = index
+			return nil
+		})
+	})
+}
+
+// ReplaceThemePipelineFunc replaces the content of a theme within the static resources of a PBIX.
+// The theme is matched by its file name, for example the base theme CY20SU09.json
+func ReplaceThemePipelineFunc(themeName string, themeJSON []byte) PipelineFunc {
+	if !json.Valid(themeJSON) {
+		return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+			return fmt.Errorf("Theme '%s' is not valid JSON", themeName)
+		}
+	}
+	return ReplaceStaticResourcePipelineFunc(themeName, themeJSON)
+}
+
+// ReplaceStaticResourcePipelineFunc replaces the content of a static resource of a PBIX, such as an image or a theme.
+// The resource is matched by its path relative to Report/StaticResources or by its file name.
+// Use StaticResourceExistsValidateFunc to check that the resource exists
+func ReplaceStaticResourcePipelineFunc(resourceName string, content []byte) PipelineFunc {
+	return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+		if isStaticResource(file.Name, resourceName) {
+			return next(file, bytes.NewReader(content))
+		}
+
+		return next(file, reader)
+	}
+}
+
+// StaticResourceExistsValidateFunc checks that a static resource replaced by ReplaceStaticResourcePipelineFunc exists in the PBIX
+func StaticResourceExistsValidateFunc(resourceName string) ValidateFunc {
+	return func(entryNames []string) error {
+		for _, entryName := range entryNames {
+			if isStaticResource(entryName, resourceName) {
+				return nil
+			}
+		}
+		return fmt.Errorf("Static resource '%s' does not exist in the report", resourceName)
+	}
+}
+
+// ReportLayoutExistsValidateFunc checks that the PBIX has a report layout, which the page PipelineFuncs edit
+func ReportLayoutExistsValidateFunc() ValidateFunc {
+	return func(entryNames []string) error {
+		for _, entryName := range entryNames {
+			if entryName == "Report/Layout" {
+				return nil
+			}
+		}
+		return fmt.Errorf("Report layout does not exist in the PBIX")
+	}
+}
+
+func isStaticResource(entryName string, resourceName string) bool {
+	const staticResourcesPrefix = "Report/StaticResources/"
+
+	return strings.HasPrefix(entryName, staticResourcesPrefix) &&
+		(strings.TrimPrefix(entryName, staticResourcesPrefix) == resourceName || path.Base(entryName) == resourceName)
+}
+
+// rewriteLayoutPipelineFunc decodes the UTF-16 Report/Layout JSON, applies the edit and encodes it again.
+// Use ReportLayoutExistsValidateFunc to check that the PBIX has a report layout
+func rewriteLayoutPipelineFunc(edit func(layout map[string]interface{}) error) PipelineFunc {
+	return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+		if file.Name != "Report/Layout" {
+			return next(file, reader)
+		}
+
+		content, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		layoutJSON, err := decodeUTF16LE(content)
+		if err != nil {
+			return err
+		}
+
+		layout, err := unmarshalJSONObject(layoutJSON)
+		if err != nil {
+			return err
+		}
+
+		err = edit(layout)
+		if err != nil {
+			return err
+		}
+
+		layoutJSON, err = marshalJSONObject(layout)
+		if err != nil {
+			return err
+		}
+		return next(file, bytes.NewReader(encodeUTF16LE(layoutJSON)))
+	}
+}
+
+func layoutSections(layout map[string]interface{}) ([]interface{}, error) {
+	sections, ok := layout["sections"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Report layout does not contain any pages")
+	}
+	return sections, nil
+}
+
+func findLayoutSection(sections []interface{}, page string) int {
+	for index, section := range sections {
+		section, ok := section.(map[string]interface{})
+		if ok && (section["name"] == page || section["displayName"] == page) {
+			return index
+		}
+	}
+	return -1
+}
+
+// rewriteJSONStringField edits a field holding a JSON object encoded as a string, as the layout does for config fields
+func rewriteJSONStringField(parent map[string]interface{}, field string, edit func(value map[string]interface{}) error) error {
+	valueJSON, _ := parent[field].(string)
+	if valueJSON == "" {
+		valueJSON = "{}"
+	}
+
+	value, err := unmarshalJSONObject(valueJSON)
+	if err != nil {
+		return err
+	}
+
+	err = edit(value)
+	if err != nil {
+		return err
+	}
+
+	valueJSON, err = marshalJSONObject(value)
+	if err != nil {
+		return err
+	}
+	parent[field] = valueJSON
+	return nil
+}
+
+func unmarshalJSONObject(content string) (map[string]interface{}, error) {
+	// numbers are kept as is, so positions and ids are not rounded
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.UseNumber()
+
+	var value map[string]interface{}
+	err := decoder.Decode(&value)
+	return value, err
+}
+
+func marshalJSONObject(value map[string]interface{}) (string, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+
+	err := encoder.Encode(value)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
@@ -0,0 +1,102 @@
+package pbixrewriter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReplaceStaticResourcePipelineFunc(t *testing.T) {
+	input := newTestPbix(t,
+		testPbixEntry{"Report/Layout", encodeUTF16LE(testLayout)},
+		testPbixEntry{"Report/StaticResources/SharedResources/BaseThemes/CY20SU09.json", []byte(`{"name":"CY20SU09"}`)},
+		testPbixEntry{"Report/StaticResources/RegisteredResources/logo.png", []byte("original")},
+	)
+
+	output, err := rewriteTestPbix(t, input,
+		ReplaceThemePipelineFunc("CY20SU09.json", []byte(`{"name":"Custom"}`)),
+		ReplaceStaticResourcePipelineFunc("RegisteredResources/logo.png", []byte("replaced")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedContents := map[string]string{
+		"Report/StaticResources/SharedResources/BaseThemes/CY20SU09.json": `{"name":"Custom"}`,
+		"Report/StaticResources/RegisteredResources/logo.png":             "replaced",
+	}
+	for _, file := range output.File {
+		expectedContent, ok := expectedContents[file.Name]
+		if !ok {
+			continue
+		}
+		content, err := readPbixItem(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != expectedContent {
+			t.Fatalf("Expected '%s' to contain '%s', found '%s'", file.Name, expectedContent, string(content))
+		}
+	}
+}
+
+func TestStaticResourceExistsValidateFunc(t *testing.T) {
+	input := newTestPbix(t,
+		testPbixEntry{"Report/Layout", encodeUTF16LE(testLayout)},
+		testPbixEntry{"Report/StaticResources/SharedResources/BaseThemes/CY20SU09.json", []byte(`{"name":"CY20SU09"}`)},
+	)
+
+	err := ValidatePbix(input, []ValidateFunc{
+		StaticResourceExistsValidateFunc("CY20SU09.json"),
+		StaticResourceExistsValidateFunc("SharedResources/BaseThemes/CY20SU09.json"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ValidatePbix(input, []ValidateFunc{StaticResourceExistsValidateFunc("nonexistent.json")})
+	if err == nil || !strings.Contains(err.Error(), "Static resource 'nonexistent.json' does not exist in the report") {
+		t.Fatalf("Expected an error for the missing theme, found %v", err)
+	}
+
+	// a resource outside the static resources is not matched
+	err = ValidatePbix(input, []ValidateFunc{StaticResourceExistsValidateFunc("Layout")})
+	if err == nil || !strings.Contains(err.Error(), "Static resource 'Layout' does not exist in the report") {
+		t.Fatalf("Expected an error for a resource outside the static resources, found %v", err)
+	}
+}
+
+func TestReportLayoutExistsValidateFunc(t *testing.T) {
+	err := ValidatePbix(newTestPbix(t, testPbixEntry{"Report/Layout", encodeUTF16LE(testLayout)}), []ValidateFunc{ReportLayoutExistsValidateFunc()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ValidatePbix(newTestPbix(t, testPbixEntry{"Version", encodeUTF16LE("1.19")}), []ValidateFunc{ReportLayoutExistsValidateFunc()})
+	if err == nil || !strings.Contains(err.Error(), "Report layout does not exist in the PBIX") {
+		t.Fatalf("Expected an error for a PBIX without a layout, found %v", err)
+	}
+}
+
+func TestSetPageVisibilityPipelineFunc(t *testing.T) {
+	input := newTestPbix(t, testPbixEntry{"Report/Layout", encodeUTF16LE(testLayout)})
+
+	output, err := rewriteTestPbix(t, input, SetPageVisibilityPipelineFunc([]PageVisibility{{Page: "Second", Hidden: true}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := InspectPbix(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, page := range info.Pages {
+		if page.Hidden != (page.Name == "second") {
+			t.Fatalf("Expected only page 'second' to be hidden, found %+v", info.Pages)
+		}
+	}
+
+	_, err = rewriteTestPbix(t, input, SetPageVisibilityPipelineFunc([]PageVisibility{{Page: "third", Hidden: true}}))
+	if err == nil || !strings.Contains(err.Error(), "Page 'third' does not exist in the report") {
+		t.Fatalf("Expected an error for the missing page, found %v", err)
+	}
+}
@@ -35,3 +35,51 @@ func SetDatasetIDPipelineFunc(datasetID string) PipelineFunc {
 		return next(file, reader)
 	}
 }
+
+// RemoveSecurityBindingsPipelineFunc removes the SecurityBindings from a PBIX
+func RemoveSecurityBindingsPipelineFunc() PipelineFunc {
+	return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+		if file.Name == "SecurityBindings" {
+			return nil
+		}
+
+		return next(file, reader)
+	}
+}
+
+// ReplaceConnectionStringPipelineFunc replaces occurrences of a connection string within the connection strings of a PBIX.
+// The connections are decoded first, so connection strings are compared and written without their JSON escaping
+func ReplaceConnectionStringPipelineFunc(originalConnectionString string, newConnectionString string) PipelineFunc {
+	return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+		if file.Name == "Connections" {
+			bytes, err := ioutil.ReadAll(reader)
+			if err != nil {
+				return err
+			}
+
+			connections, err := unmarshalJSONObject(string(bytes))
+			if err != nil {
+				return err
+			}
+
+			connectionList, _ := connections["Connections"].([]interface{})
+			for _, connection := range connectionList {
+				connection, ok := connection.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if connectionString, ok := connection["ConnectionString"].(string); ok {
+					connection["ConnectionString"] = strings.ReplaceAll(connectionString, originalConnectionString, newConnectionString)
+				}
+			}
+
+			connectionsJSON, err := marshalJSONObject(connections)
+			if err != nil {
+				return err
+			}
+			return next(file, strings.NewReader(connectionsJSON))
+		}
+
+		return next(file, reader)
+	}
+}
@@ -0,0 +1,83 @@
+package pbixrewriter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestReplaceConnectionStringPipelineFunc_escapedCharacters(t *testing.T) {
+	tests := []struct {
+		name                     string
+		connectionString         string
+		originalConnectionString string
+		newConnectionString      string
+		expectedConnectionString string
+	}{
+		{
+			name:                     "original contains backslash",
+			connectionString:         `Data Source=server\instance;Initial Catalog=sales`,
+			originalConnectionString: `Data Source=server\instance`,
+			newConnectionString:      `Data Source=other`,
+			expectedConnectionString: `Data Source=other;Initial Catalog=sales`,
+		},
+		{
+			name:                     "original contains quotes",
+			connectionString:         `Provider="MSOLAP";Data Source=server`,
+			originalConnectionString: `Provider="MSOLAP"`,
+			newConnectionString:      `Provider=MSOLAP.8`,
+			expectedConnectionString: `Provider=MSOLAP.8;Data Source=server`,
+		},
+		{
+			name:                     "replacement contains backslash and quotes",
+			connectionString:         `Data Source=server;Initial Catalog=sales`,
+			originalConnectionString: `Data Source=server`,
+			newConnectionString:      `Data Source="other\instance"`,
+			expectedConnectionString: `Data Source="other\instance";Initial Catalog=sales`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			connectionsJSON, err := json.Marshal(map[string]interface{}{
+				"Version": 1,
+				"Connections": []map[string]interface{}{
+					{
+						"Name":             "EntityDataSource",
+						"ConnectionString": test.connectionString,
+						"ConnectionType":   "analysisServicesDatabaseLive",
+					},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			output, err := rewriteTestPbix(t, newTestPbix(t,
+				testPbixEntry{name: "Connections", content: connectionsJSON},
+			), ReplaceConnectionStringPipelineFunc(test.originalConnectionString, test.newConnectionString))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			content, err := readPbixItem(output.File[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var connections pbixConnectionsJSON
+			err = json.Unmarshal(content, &connections)
+			if err != nil {
+				t.Fatalf("rewritten connections are not valid JSON: %v\n%s", err, content)
+			}
+			if len(connections.Connections) != 1 {
+				t.Fatalf("expected 1 connection, got %d", len(connections.Connections))
+			}
+			if connections.Connections[0].ConnectionString != test.expectedConnectionString {
+				t.Errorf("expected connection string '%s', got '%s'", test.expectedConnectionString, connections.Connections[0].ConnectionString)
+			}
+			if connections.Connections[0].ConnectionType != "analysisServicesDatabaseLive" {
+				t.Errorf("expected other connection fields to be kept, got connection type '%s'", connections.Connections[0].ConnectionType)
+			}
+		})
+	}
+}
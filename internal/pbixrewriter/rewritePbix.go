@@ -2,8 +2,12 @@ package pbixrewriter
 
 import (
 	"archive/zip"
+	"encoding/binary"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 )
 
 // PipelineFunc defines a unit of processing for rewriting PBIX files
@@ -12,24 +16,89 @@ type PipelineFunc func(file *zip.File, reader io.Reader, next PipelineFuncNext)
 // PipelineFuncNext defines the next function inside a PipelineFunc
 type PipelineFuncNext func(file *zip.File, reader io.Reader) error
 
+// ValidateFunc checks a PBIX before it is rewritten, given the names of all its entries.
+// PipelineFuncs only see the entries that exist, so ValidateFuncs report entries they require but are missing
+type ValidateFunc func(entryNames []string) error
+
 // RewritePbix rewrites a PBIX file applying the given PipelineFuncs
 func RewritePbix(input *zip.Reader, output *zip.Writer, pipelineFuncs []PipelineFunc) error {
+	pipeline := nestPipelineFunc(0, append(pipelineFuncs, buildWriterPipelineFunc(output)))
+
+	for _, inputItem := range input.File {
+		err := rewritePbixItem(inputItem, pipeline)
+		if err != nil {
+			return fmt.Errorf("Unable to rewrite '%s': %w", inputItem.Name, err)
+		}
+	}
+	return nil
+}
+
+// ValidatePbix checks a PBIX file applying the given ValidateFuncs
+func ValidatePbix(input *zip.Reader, validateFuncs []ValidateFunc) error {
+	var entryNames []string
 	for _, inputItem := range input.File {
-		inputItemReader, err := inputItem.Open()
+		entryNames = append(entryNames, inputItem.Name)
+	}
+
+	for _, validateFunc := range validateFuncs {
+		err := validateFunc(entryNames)
 		if err != nil {
 			return err
 		}
-		defer inputItemReader.Close()
+	}
+	return nil
+}
+
+// ValidatePbixFile checks a PBIX file applying the given ValidateFuncs
+func ValidatePbixFile(inputPbixFile string, validateFuncs []ValidateFunc) error {
+	zipReader, err := zip.OpenReader(inputPbixFile)
+	if err != nil {
+		return err
+	}
+	defer zipReader.Close()
 
-		pipeline := nestPipelineFunc(0, append(pipelineFuncs, buildWriterPipelineFunc(output)))
+	return ValidatePbix(&zipReader.Reader, validateFuncs)
+}
+
+func rewritePbixItem(inputItem *zip.File, pipeline PipelineFuncNext) error {
+	inputItemReader := &itemReader{file: inputItem}
+	defer inputItemReader.Close()
 
-		pipeline(inputItem, inputItemReader)
+	return pipeline(inputItem, inputItemReader)
+}
+
+// itemReader only decompresses a zip entry once it is read, so that entries passed through
+// the pipeline without being read can be copied as is
+type itemReader struct {
+	file   *zip.File
+	reader io.ReadCloser
+}
 
+func (r *itemReader) Read(p []byte) (int, error) {
+	if r.reader == nil {
+		reader, err := r.file.Open()
+		if err != nil {
+			return 0, err
+		}
+		r.reader = reader
 	}
-	return nil
+	return r.reader.Read(p)
+}
+
+func (r *itemReader) Close() error {
+	if r.reader == nil {
+		return nil
+	}
+	return r.reader.Close()
 }
 
-// RewritePbixFiles rewrites a PBIX file applying the given PipelineFuncs
+func (r *itemReader) isUnread(file *zip.File) bool {
+	return r.file == file && r.reader == nil
+}
+
+// RewritePbixFiles rewrites a PBIX file applying the given PipelineFuncs.
+// The output is written to a temporary file in the same directory and renamed once complete,
+// so the output file is never left partially written
 func RewritePbixFiles(inputPbixFile string, outputPbixFile string, pipelineFuncs []PipelineFunc) error {
 	zipReader, err := zip.OpenReader(inputPbixFile)
 	if err != nil {
@@ -37,26 +106,80 @@ func RewritePbixFiles(inputPbixFile string, outputPbixFile string, pipelineFuncs
 	}
 	defer zipReader.Close()
 
-	targetFile, err := os.Create(outputPbixFile)
+	targetFile, err := ioutil.TempFile(filepath.Dir(outputPbixFile), filepath.Base(outputPbixFile)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tempFileName := targetFile.Name()
+
+	err = writePbix(&zipReader.Reader, targetFile, pipelineFuncs)
 	if err != nil {
+		os.Remove(tempFileName)
 		return err
 	}
+
+	// temporary files are only readable by their owner, so use the mode of the file being replaced or the usual mode of a new file
+	err = os.Chmod(tempFileName, outputPbixFileMode(outputPbixFile))
+	if err != nil {
+		os.Remove(tempFileName)
+		return err
+	}
+
+	err = os.Rename(tempFileName, outputPbixFile)
+	if err != nil {
+		os.Remove(tempFileName)
+		return err
+	}
+	return nil
+}
+
+func outputPbixFileMode(outputPbixFile string) os.FileMode {
+	if outputInfo, err := os.Stat(outputPbixFile); err == nil {
+		return outputInfo.Mode().Perm()
+	}
+	return 0644
+}
+
+func writePbix(input *zip.Reader, targetFile *os.File, pipelineFuncs []PipelineFunc) error {
 	targetZipWriter := zip.NewWriter(targetFile)
-	defer targetZipWriter.Close()
 
-	return RewritePbix(&zipReader.Reader, targetZipWriter, pipelineFuncs)
+	err := RewritePbix(input, targetZipWriter, pipelineFuncs)
+	if err != nil {
+		targetZipWriter.Close()
+		targetFile.Close()
+		return err
+	}
+
+	// closing the zip writer writes the central directory, without it the file is truncated
+	err = targetZipWriter.Close()
+	if err != nil {
+		targetFile.Close()
+		return err
+	}
+
+	return targetFile.Close()
 }
 
 func buildWriterPipelineFunc(writer *zip.Writer) PipelineFunc {
 
 	return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
-		header, err := zip.FileInfoHeader(file.FileInfo())
-		if err != nil {
-			return err
+
+		// unchanged entries are copied byte for byte without recompressing
+		if itemReader, ok := reader.(*itemReader); ok && itemReader.isUnread(file) {
+			return copyRawItem(writer, file)
 		}
-		header.Name = file.Name
 
-		outputItemWriter, err := writer.CreateHeader(header)
+		// keep the original header (method, times, comments) but let the writer
+		// compute the checksum and sizes of the new content
+		header := file.FileHeader
+		header.CRC32 = 0
+		header.CompressedSize = 0
+		header.CompressedSize64 = 0
+		header.UncompressedSize = 0
+		header.UncompressedSize64 = 0
+		header.Extra = removeZip64ExtraField(header.Extra)
+
+		outputItemWriter, err := writer.CreateHeader(&header)
 		if err != nil {
 			return err
 		}
@@ -68,6 +191,41 @@ func buildWriterPipelineFunc(writer *zip.Writer) PipelineFunc {
 	}
 }
 
+func copyRawItem(writer *zip.Writer, file *zip.File) error {
+	rawReader, err := file.OpenRaw()
+	if err != nil {
+		return err
+	}
+
+	header := file.FileHeader
+	outputItemWriter, err := writer.CreateRaw(&header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(outputItemWriter, rawReader)
+	return err
+}
+
+// removeZip64ExtraField removes the zip64 sizes from the extra field, as the writer adds its own
+// when the rewritten content requires it
+func removeZip64ExtraField(extra []byte) []byte {
+	const zip64ExtraID = 0x0001
+
+	var result []byte
+	for len(extra) >= 4 {
+		tag := binary.LittleEndian.Uint16(extra[0:2])
+		size := int(binary.LittleEndian.Uint16(extra[2:4]))
+		if len(extra) < 4+size {
+			break
+		}
+		if tag != zip64ExtraID {
+			result = append(result, extra[:4+size]...)
+		}
+		extra = extra[4+size:]
+	}
+	return result
+}
+
 func nestPipelineFunc(index int, pipelineFuncs []PipelineFunc) PipelineFuncNext {
 	return func(file *zip.File, reader io.Reader) error {
 		nextIndex := index + 1
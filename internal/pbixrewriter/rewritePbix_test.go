@@ -0,0 +1,358 @@
+package pbixrewriter
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTestPbixFile writes a PBIX containing the entries to a file in the directory
+func writeTestPbixFile(t *testing.T, dir string, name string, entries ...testPbixEntry) string {
+	t.Helper()
+
+	pbixFile := filepath.Join(dir, name)
+	err := ioutil.WriteFile(pbixFile, newTestPbixContent(t, entries...), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pbixFile
+}
+
+func failingPipelineFunc(entryName string, err error) PipelineFunc {
+	return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+		if file.Name == entryName {
+			return err
+		}
+		return next(file, reader)
+	}
+}
+
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+	return names
+}
+
+func TestRewritePbix_errorIncludesEntryName(t *testing.T) {
+	input := newTestPbix(t,
+		testPbixEntry{"Version", encodeUTF16LE("1.19")},
+		testPbixEntry{"Connections", []byte(`{}`)},
+	)
+	pipelineErr := errors.New("pipeline failed")
+
+	_, err := rewriteTestPbix(t, input, failingPipelineFunc("Connections", pipelineErr))
+	if err == nil || !strings.Contains(err.Error(), "Unable to rewrite 'Connections'") {
+		t.Fatalf("Expected the error to name the entry, found %v", err)
+	}
+	if !errors.Is(err, pipelineErr) {
+		t.Fatalf("Expected the error to wrap the pipeline error, found %v", err)
+	}
+}
+
+func TestRewritePbix_stopsAtFailingEntry(t *testing.T) {
+	input := newTestPbix(t,
+		testPbixEntry{"Version", encodeUTF16LE("1.19")},
+		testPbixEntry{"Connections", []byte(`{}`)},
+		testPbixEntry{"Report/Layout", encodeUTF16LE(`{}`)},
+	)
+
+	var rewritten []string
+	recordPipelineFunc := func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+		rewritten = append(rewritten, file.Name)
+		return next(file, reader)
+	}
+
+	_, err := rewriteTestPbix(t, input, recordPipelineFunc, failingPipelineFunc("Connections", errors.New("pipeline failed")))
+	if err == nil {
+		t.Fatal("Expected the rewrite to fail")
+	}
+	if len(rewritten) != 2 || rewritten[0] != "Version" || rewritten[1] != "Connections" {
+		t.Fatalf("Expected the rewrite to stop at the failing entry, found %v", rewritten)
+	}
+}
+
+func TestRewritePbixFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pbixrewriter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	inputPbixFile := writeTestPbixFile(t, dir, "input.pbix",
+		testPbixEntry{"Version", encodeUTF16LE("1.19")},
+		testPbixEntry{"Connections", []byte(`{"Connections":[{"ConnectionString":"Data Source=original"}]}`)},
+	)
+	outputPbixFile := filepath.Join(dir, "output.pbix")
+
+	err = RewritePbixFiles(inputPbixFile, outputPbixFile, []PipelineFunc{
+		ReplaceConnectionStringPipelineFunc("Data Source=original", "Data Source=replaced"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the temporary file is renamed to the output
+	names := listDir(t, dir)
+	if len(names) != 2 || names[0] != "input.pbix" || names[1] != "output.pbix" {
+		t.Fatalf("Expected only the input and output files, found %v", names)
+	}
+
+	output, err := zip.OpenReader(outputPbixFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+	for _, file := range output.File {
+		if file.Name != "Connections" {
+			continue
+		}
+		content, err := readPbixItem(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(content), "Data Source=replaced") {
+			t.Fatalf("Expected the connection string to be replaced, found %s", string(content))
+		}
+	}
+
+	if runtime.GOOS != "windows" {
+		outputInfo, err := os.Stat(outputPbixFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if outputInfo.Mode().Perm() != 0644 {
+			t.Fatalf("Expected the output to have mode 0644, found %v", outputInfo.Mode().Perm())
+		}
+	}
+}
+
+func TestRewritePbixFiles_failureKeepsExistingOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pbixrewriter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	inputPbixFile := writeTestPbixFile(t, dir, "input.pbix",
+		testPbixEntry{"Version", encodeUTF16LE("1.19")},
+		testPbixEntry{"Connections", []byte(`{}`)},
+	)
+	outputPbixFile := filepath.Join(dir, "output.pbix")
+	err = ioutil.WriteFile(outputPbixFile, []byte("previous output"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = RewritePbixFiles(inputPbixFile, outputPbixFile, []PipelineFunc{
+		failingPipelineFunc("Connections", errors.New("pipeline failed")),
+	})
+	if err == nil || !strings.Contains(err.Error(), "Unable to rewrite 'Connections'") {
+		t.Fatalf("Expected the rewrite to fail, found %v", err)
+	}
+
+	// the temporary file is removed and the previous output is left untouched
+	names := listDir(t, dir)
+	if len(names) != 2 || names[0] != "input.pbix" || names[1] != "output.pbix" {
+		t.Fatalf("Expected only the input and output files, found %v", names)
+	}
+	content, err := ioutil.ReadFile(outputPbixFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "previous output" {
+		t.Fatalf("Expected the previous output to be kept, found %s", string(content))
+	}
+}
+
+func TestRewritePbixFiles_invalidInputCreatesNoOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pbixrewriter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	inputPbixFile := filepath.Join(dir, "input.pbix")
+	err = ioutil.WriteFile(inputPbixFile, []byte("not a zip"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = RewritePbixFiles(inputPbixFile, filepath.Join(dir, "output.pbix"), nil)
+	if err == nil {
+		t.Fatal("Expected the rewrite to fail")
+	}
+
+	names := listDir(t, dir)
+	if len(names) != 1 || names[0] != "input.pbix" {
+		t.Fatalf("Expected only the input file, found %v", names)
+	}
+}
+
+func TestRewritePbixFiles_keepsModeOfReplacedOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("File modes are not supported on windows")
+	}
+
+	dir, err := ioutil.TempDir("", "pbixrewriter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	inputPbixFile := writeTestPbixFile(t, dir, "input.pbix", testPbixEntry{"Version", encodeUTF16LE("1.19")})
+	outputPbixFile := filepath.Join(dir, "output.pbix")
+	err = ioutil.WriteFile(outputPbixFile, []byte("previous output"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chmod(outputPbixFile, 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = RewritePbixFiles(inputPbixFile, outputPbixFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputInfo, err := os.Stat(outputPbixFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outputInfo.Mode().Perm() != 0640 {
+		t.Fatalf("Expected the output to keep mode 0640, found %v", outputInfo.Mode().Perm())
+	}
+}
+
+func readRawPbixItem(t *testing.T, file *zip.File) []byte {
+	t.Helper()
+
+	rawReader, err := file.OpenRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadAll(rawReader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+// newTestPbixWithHeaders builds an in memory PBIX with the given headers, mixing stored and deflated entries
+// with different modified times and comments, as Power BI Desktop does
+func newTestPbixWithHeaders(t *testing.T, entries ...testPbixEntry) *zip.Reader {
+	t.Helper()
+
+	modified := time.Date(2020, 9, 14, 10, 30, 0, 0, time.UTC)
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for index, entry := range entries {
+		method := zip.Deflate
+		if index%2 == 0 {
+			method = zip.Store
+		}
+		entryWriter, err := writer.CreateHeader(&zip.FileHeader{
+			Name:     entry.name,
+			Method:   method,
+			Modified: modified.Add(time.Duration(index) * time.Hour),
+			Comment:  "comment for " + entry.name,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = entryWriter.Write(entry.content)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := writer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reader
+}
+
+func TestRewritePbix_preservesHeaders(t *testing.T) {
+	input := newTestPbixWithHeaders(t,
+		testPbixEntry{"Version", encodeUTF16LE("1.19")},
+		testPbixEntry{"Connections", []byte(`{"Version":1,"Connections":[{"Name":"EntityDataSource","ConnectionString":"Data Source=pbiazure://api.powerbi.com;Initial Catalog=11111111-1111-1111-1111-111111111111;","ConnectionType":"pbiServiceLive","PbiModelDatabaseName":"11111111-1111-1111-1111-111111111111"}]}`)},
+		testPbixEntry{"SecurityBindings", []byte{1, 2, 3, 4}},
+		testPbixEntry{"Report/Layout", encodeUTF16LE(strings.Repeat(testLayout, 10))},
+		testPbixEntry{"Settings", encodeUTF16LE(`{"Version":4}`)},
+	)
+
+	output, err := rewriteTestPbix(t, input, SetDatasetIDPipelineFunc("00000000-0000-0000-0000-000000000000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputFiles := make(map[string]*zip.File)
+	for _, file := range output.File {
+		outputFiles[file.Name] = file
+	}
+
+	for _, inputFile := range input.File {
+		outputFile, ok := outputFiles[inputFile.Name]
+		if inputFile.Name == "SecurityBindings" {
+			if ok {
+				t.Fatal("Expected SecurityBindings to be removed")
+			}
+			continue
+		}
+		if !ok {
+			t.Fatalf("Expected '%s' to be in the output", inputFile.Name)
+		}
+
+		// rewritten entries keep their method, times and comments, only the content changes
+		if outputFile.Method != inputFile.Method {
+			t.Fatalf("Expected '%s' to keep method %d, found %d", inputFile.Name, inputFile.Method, outputFile.Method)
+		}
+		if outputFile.Comment != inputFile.Comment {
+			t.Fatalf("Expected '%s' to keep comment '%s', found '%s'", inputFile.Name, inputFile.Comment, outputFile.Comment)
+		}
+		if outputFile.ModifiedTime != inputFile.ModifiedTime || outputFile.ModifiedDate != inputFile.ModifiedDate || !outputFile.Modified.Equal(inputFile.Modified) {
+			t.Fatalf("Expected '%s' to keep modified time %v, found %v", inputFile.Name, inputFile.Modified, outputFile.Modified)
+		}
+		if inputFile.Name == "Connections" {
+			content, err := readPbixItem(outputFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(content), "00000000-0000-0000-0000-000000000000") {
+				t.Fatalf("Expected the dataset to be replaced in Connections, found %s", string(content))
+			}
+			continue
+		}
+
+		// unchanged entries are copied byte for byte
+		if outputFile.CRC32 != inputFile.CRC32 ||
+			outputFile.CompressedSize64 != inputFile.CompressedSize64 ||
+			outputFile.UncompressedSize64 != inputFile.UncompressedSize64 {
+			t.Fatalf("Expected '%s' to keep its CRC and sizes, found %+v was %+v", inputFile.Name, outputFile.FileHeader, inputFile.FileHeader)
+		}
+		if !bytes.Equal(readRawPbixItem(t, outputFile), readRawPbixItem(t, inputFile)) {
+			t.Fatalf("Expected '%s' to be copied without recompressing", inputFile.Name)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package pbixrewriter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+)
+
+var utf16LEByteOrderMark = []byte{0xFF, 0xFE}
+
+// decodeUTF16LE decodes the little endian UTF-16 content used by PBIX entries such as Version and Report/Layout
+func decodeUTF16LE(content []byte) (string, error) {
+	content = bytes.TrimPrefix(content, utf16LEByteOrderMark)
+	if len(content)%2 != 0 {
+		return "", fmt.Errorf("content is not valid UTF-16, it has an odd number of bytes")
+	}
+
+	codeUnits := make([]uint16, len(content)/2)
+	for i := range codeUnits {
+		codeUnits[i] = binary.LittleEndian.Uint16(content[i*2:])
+	}
+	return string(utf16.Decode(codeUnits)), nil
+}
+
+// encodeUTF16LE encodes a string as little endian UTF-16 without a byte order mark, as written by Power BI Desktop
+func encodeUTF16LE(content string) []byte {
+	codeUnits := utf16.Encode([]rune(content))
+	result := make([]byte, len(codeUnits)*2)
+	for i, codeUnit := range codeUnits {
+		binary.LittleEndian.PutUint16(result[i*2:], codeUnit)
+	}
+	return result
+}
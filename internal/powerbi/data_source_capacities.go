@@ -0,0 +1,155 @@
+package powerbi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// DataSourceCapacities represents the Power BI capacities the user has access to
+func DataSourceCapacities() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCapacitiesRead,
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "If set, only capacities with this display name are returned.",
+			},
+			"sku": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "If set, only capacities with this SKU (for example `A1` or `P1`) are returned.",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "If set, only capacities in this Azure region are returned.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "If set, only capacities in this state (for example `Active` or `Suspended`) are returned.",
+			},
+			"capacities": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The capacities matching the filters.",
+				Elem: &schema.Resource{
+					Schema: capacitySchema(),
+				},
+			},
+		},
+	}
+}
+
+func capacitySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The capacity ID.",
+		},
+		"display_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The display name of the capacity.",
+		},
+		"sku": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The capacity SKU.",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The Azure region where the capacity was provisioned.",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The capacity state.",
+		},
+		"admins": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The capacity admins.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"capacity_user_access_right": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The access right the user has on the capacity.",
+		},
+	}
+}
+
+func dataSourceCapacitiesRead(d *schema.ResourceData, meta interface{}) error {
+	capacities, err := getFilteredCapacities(d, meta)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(capacities))
+	for _, capacity := range capacities {
+		ids = append(ids, capacity.ID)
+	}
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(strings.Join(ids, ","))))
+	d.Set("capacities", genericMap(capacities, flattenCapacity))
+
+	return nil
+}
+
+func getFilteredCapacities(d *schema.ResourceData, meta interface{}) ([]powerbiapi.GetCapacitiesResponseItem, error) {
+	client := meta.(*powerbiapi.Client)
+
+	capacityList, err := client.GetCapacities()
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := d.Get("display_name").(string)
+	sku := d.Get("sku").(string)
+	region := d.Get("region").(string)
+	state := d.Get("state").(string)
+
+	result := make([]powerbiapi.GetCapacitiesResponseItem, 0)
+	for _, capacity := range capacityList.Value {
+		if displayName != "" && capacity.DisplayName != displayName {
+			continue
+		}
+		if sku != "" && !strings.EqualFold(capacity.SKU, sku) {
+			continue
+		}
+		if region != "" && !strings.EqualFold(capacity.Region, region) {
+			continue
+		}
+		if state != "" && !strings.EqualFold(capacity.State, state) {
+			continue
+		}
+		result = append(result, capacity)
+	}
+
+	return result, nil
+}
+
+func flattenCapacity(capacity powerbiapi.GetCapacitiesResponseItem) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           capacity.ID,
+		"display_name": capacity.DisplayName,
+		"sku":          capacity.SKU,
+		"region":       capacity.Region,
+		"state":        capacity.State,
+		"admins": genericMap(capacity.Admins, func(admin powerbiapi.CapacityAdmins) string {
+			return string(admin)
+		}),
+		"capacity_user_access_right": capacity.CapacityUserAccessRight,
+	}
+}
@@ -0,0 +1,82 @@
+package powerbi
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccDataSourceCapacities_basic(t *testing.T) {
+	premiumCapacityID := os.Getenv("POWERBI_CAPACITY_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCapacity(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerbi_capacities" "test" {
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.powerbi_capacities.test", "id"),
+					testCheckCapacityInList("data.powerbi_capacities.test", premiumCapacityID),
+				),
+			},
+			{
+				Config: `
+				data "powerbi_capacities" "test" {
+					display_name = "capacity-that-should-not-exist"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_capacities.test", "capacities.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPreCheckCapacity(t *testing.T) {
+	switch strings.ToLower(os.Getenv("POWERBI_IS_PREMIUM")) {
+	case "":
+		t.Fatal("POWERBI_IS_PREMIUM must be set for capacity acceptance tests")
+	case "true":
+		if os.Getenv("POWERBI_CAPACITY_ID") == "" {
+			t.Fatal("POWERBI_CAPACITY_ID must be set when POWERBI_IS_PREMIUM is set to \"true\" for capacity acceptance tests")
+		}
+	case "false":
+		t.Skip("Capacity acceptance tests skipped")
+	default:
+		t.Fatal("POWERBI_IS_PREMIUM must be set to either \"true\" or \"false\"")
+	}
+}
+
+func testCheckCapacityInList(dataSourceName string, expectedCapacityID string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[dataSourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", dataSourceName)
+		}
+
+		count, err := strconv.Atoi(rs.Primary.Attributes["capacities.#"])
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < count; i++ {
+			if rs.Primary.Attributes[fmt.Sprintf("capacities.%d.id", i)] == expectedCapacityID {
+				return nil
+			}
+		}
+		return fmt.Errorf("Expecting capacity %s in %s. Not found", expectedCapacityID, dataSourceName)
+	}
+}
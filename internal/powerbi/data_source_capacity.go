@@ -0,0 +1,78 @@
+package powerbi
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// DataSourceCapacity represents a single Power BI capacity
+func DataSourceCapacity() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCapacityRead,
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The display name of the capacity.",
+			},
+			"sku": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The capacity SKU (for example `A1` or `P1`).",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The Azure region where the capacity was provisioned.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The capacity state (for example `Active` or `Suspended`).",
+			},
+			"admins": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The capacity admins.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"capacity_user_access_right": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The access right the user has on the capacity.",
+			},
+		},
+	}
+}
+
+func dataSourceCapacityRead(d *schema.ResourceData, meta interface{}) error {
+	capacities, err := getFilteredCapacities(d, meta)
+	if err != nil {
+		return err
+	}
+
+	if len(capacities) == 0 {
+		return fmt.Errorf("No capacity matched the specified filters")
+	}
+	if len(capacities) > 1 {
+		return fmt.Errorf("%d capacities matched the specified filters. Refine the filters so only a single capacity matches", len(capacities))
+	}
+
+	capacity := flattenCapacity(capacities[0])
+	d.SetId(capacity["id"].(string))
+	for key, value := range capacity {
+		if key != "id" {
+			d.Set(key, value)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package powerbi
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceCapacity_basic(t *testing.T) {
+	premiumCapacityID := os.Getenv("POWERBI_CAPACITY_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCapacity(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "powerbi_capacities" "all" {
+				}
+
+				data "powerbi_capacity" "test" {
+					display_name = [for c in data.powerbi_capacities.all.capacities : c.display_name if c.id == "%s"][0]
+				}
+				`, premiumCapacityID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_capacity.test", "id", premiumCapacityID),
+					resource.TestCheckResourceAttrSet("data.powerbi_capacity.test", "sku"),
+					resource.TestCheckResourceAttrSet("data.powerbi_capacity.test", "capacity_user_access_right"),
+				),
+			},
+		},
+	})
+}
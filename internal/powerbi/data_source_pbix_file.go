@@ -0,0 +1,250 @@
+package powerbi
+
+import (
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/pbixrewriter"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// DataSourcePBIXFile represents the metadata of a local PBIX file
+func DataSourcePBIXFile() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePBIXFileRead,
+
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "A path to a PBIX file on the local system. The file is read locally without calling the Power BI service.",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the PBIX file format.",
+			},
+			"has_data_model": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the PBIX contains a data model. Thin reports using a live connection do not.",
+			},
+			"connections": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The connections of the PBIX, such as the live connection of a thin report.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The connection name.",
+						},
+						"connection_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The connection type. For example `pbiServiceLive`.",
+						},
+						"connection_string": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The connection string.",
+						},
+						"dataset_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the dataset the connection targets. Empty if the connection does not target a Power BI dataset.",
+						},
+					},
+				},
+			},
+			"pages": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The pages of the report, in display order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The internal page name.",
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The page name shown in the report.",
+						},
+						"ordinal": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The position of the page in the report.",
+						},
+						"hidden": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the page is hidden.",
+						},
+						"visuals": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The visuals on the page.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The visual name.",
+									},
+									"visual_type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The visual type. For example `tableEx`, `card` or `group`.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"active_page_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The internal name of the page the report opens on.",
+			},
+			"tables": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The tables of the data model. Only available when the PBIX contains a `DataModelSchema`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The table name.",
+						},
+						"m_expressions": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The M queries of the table partitions.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"parameters": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The parameters of the data model. Only available when the PBIX contains a `DataModelSchema`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The parameter name.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The parameter type. For example `Text` or `Number`.",
+						},
+						"is_required": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the parameter requires a value.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The parameter value as saved in the PBIX.",
+						},
+					},
+				},
+			},
+			"expressions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The shared expressions of the data model, such as M queries and parameters. Only available when the PBIX contains a `DataModelSchema`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The expression name.",
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The expression kind. For example `m`.",
+						},
+						"expression": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The expression.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePBIXFileRead(d *schema.ResourceData, meta interface{}) error {
+	source := d.Get("source").(string)
+
+	info, err := pbixrewriter.InspectPbixFile(source)
+	if err != nil {
+		return err
+	}
+
+	contentHash, err := calculateContentHash(source)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(contentHash)
+	d.Set("version", info.Version)
+	d.Set("has_data_model", info.HasDataModel)
+	d.Set("active_page_name", info.ActivePageName)
+	d.Set("connections", genericMap(info.Connections, func(connection pbixrewriter.PbixConnection) map[string]interface{} {
+		return map[string]interface{}{
+			"name":              connection.Name,
+			"connection_type":   connection.ConnectionType,
+			"connection_string": connection.ConnectionString,
+			"dataset_id":        connection.DatasetID,
+		}
+	}))
+	d.Set("pages", genericMap(info.Pages, func(page pbixrewriter.PbixPage) map[string]interface{} {
+		return map[string]interface{}{
+			"name":         page.Name,
+			"display_name": page.DisplayName,
+			"ordinal":      page.Ordinal,
+			"hidden":       page.Hidden,
+			"visuals": genericMap(page.Visuals, func(visual pbixrewriter.PbixVisual) map[string]interface{} {
+				return map[string]interface{}{
+					"name":        visual.Name,
+					"visual_type": visual.VisualType,
+				}
+			}),
+		}
+	}))
+	d.Set("tables", genericMap(info.Tables, func(table pbixrewriter.PbixTable) map[string]interface{} {
+		return map[string]interface{}{
+			"name":          table.Name,
+			"m_expressions": table.MExpressions,
+		}
+	}))
+	d.Set("parameters", genericMap(info.Parameters, func(parameter pbixrewriter.PbixParameter) map[string]interface{} {
+		return map[string]interface{}{
+			"name":        parameter.Name,
+			"type":        parameter.Type,
+			"is_required": parameter.IsRequired,
+			"value":       parameter.Value,
+		}
+	}))
+	d.Set("expressions", genericMap(info.Expressions, func(expression pbixrewriter.PbixExpression) map[string]interface{} {
+		return map[string]interface{}{
+			"name":       expression.Name,
+			"kind":       expression.Kind,
+			"expression": expression.Expression,
+		}
+	}))
+
+	return nil
+}
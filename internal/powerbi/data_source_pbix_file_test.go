@@ -0,0 +1,50 @@
+package powerbi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourcePBIXFile_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// first step inspects a thin report
+			{
+				Config: `
+				data "powerbi_pbix_file" "test" {
+					source = "./resource_pbix_report_only.pbix"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_pbix_file.test", "version", "1.19"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_file.test", "has_data_model", "false"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_file.test", "connections.#", "1"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_file.test", "connections.0.connection_type", "pbiServiceLive"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_file.test", "connections.0.dataset_id", "26aaaf81-f175-4bda-b6ee-047c22f5b330"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_file.test", "pages.#", "1"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_file.test", "pages.0.display_name", "Page 1"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_file.test", "pages.0.hidden", "false"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_file.test", "pages.0.visuals.0.visual_type", "tableEx"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_file.test", "active_page_name", "ReportSection"),
+				),
+			},
+			// second step inspects a report with an embedded data model
+			{
+				Config: `
+				data "powerbi_pbix_file" "test" {
+					source = "./resource_pbix_test_sample1.pbix"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_pbix_file.test", "version", "1.17"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_file.test", "has_data_model", "true"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_file.test", "connections.#", "0"),
+					resource.TestCheckResourceAttrSet("data.powerbi_pbix_file.test", "pages.0.name"),
+				),
+			},
+		},
+	})
+}
@@ -1,6 +1,9 @@
 package powerbi
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
@@ -11,10 +14,19 @@ func DataSourceWorkspace() *schema.Resource {
 		Read: dataSourceWorkspaceRead,
 
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "ID of the workspace.",
+				ExactlyOneOf: []string{"id", "name"},
+			},
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of the workspace.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Name of the workspace.",
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"capacity_id": {
 				Type:        schema.TypeString,
@@ -22,29 +34,172 @@ func DataSourceWorkspace() *schema.Resource {
 				Computed:    true,
 				Description: "Capacity ID to be assigned to workspace.",
 			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The users that have access to the workspace.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identifier": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Identifier of the principal.",
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Display name of the principal.",
+						},
+						"email_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Email address of the user.",
+						},
+						"group_user_access_right": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "User access level to workspace.",
+						},
+						"principal_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The principal type.",
+						},
+					},
+				},
+			},
+			"datasets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The datasets within the workspace.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The dataset ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The dataset name.",
+						},
+						"configured_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The dataset owner.",
+						},
+						"is_refreshable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the dataset can be refreshed.",
+						},
+					},
+				},
+			},
+			"reports": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The reports within the workspace.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The report ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The report name.",
+						},
+						"dataset_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the dataset the report is bound to.",
+						},
+						"web_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The web URL of the report.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
 func dataSourceWorkspaceRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*powerbiapi.Client)
-	name := d.Get("name").(string)
-	workspace, err := client.GetGroupByName(name)
+
+	var filter string
+	if id, ok := d.GetOk("id"); ok {
+		filter = fmt.Sprintf("id eq '%s'", id.(string))
+	} else {
+		filter = fmt.Sprintf("name eq '%s'", strings.ReplaceAll(d.Get("name").(string), "'", "''"))
+	}
+
+	groups, err := client.GetGroups(filter, -1, 0)
 	if err != nil {
 		return err
 	}
 
-	if workspace == nil {
-		d.SetId("")
+	if len(groups.Value) == 0 {
+		return fmt.Errorf("No workspace found matching %s", filter)
+	}
+	if len(groups.Value) > 1 {
+		return fmt.Errorf("%d workspaces found matching %s. Use the workspace id to select a single workspace", len(groups.Value), filter)
+	}
+
+	workspace := groups.Value[0]
+	d.SetId(workspace.ID)
+	d.Set("name", workspace.Name)
+	if workspace.IsOnDedicatedCapacity {
+		d.Set("capacity_id", workspace.CapacityID)
 	} else {
-		d.SetId(workspace.ID)
-		d.Set("name", workspace.Name)
-		if workspace.IsOnDedicatedCapacity {
-			d.Set("capacity_id", workspace.CapacityID)
-		} else {
-			d.Set("capacity_id", "")
+		d.Set("capacity_id", "")
+	}
+
+	users, err := client.GetGroupUsers(workspace.ID)
+	if err != nil {
+		return err
+	}
+	d.Set("users", genericMap(users.Value, func(user powerbiapi.GetGroupUsersResponseItem) map[string]interface{} {
+		return map[string]interface{}{
+			"identifier":              user.Identifier,
+			"display_name":            user.DisplayName,
+			"email_address":           user.EmailAddress,
+			"group_user_access_right": user.GroupUserAccessRight,
+			"principal_type":          user.PrincipalType,
 		}
+	}))
+
+	datasets, err := client.GetDatasetsInGroup(workspace.ID)
+	if err != nil {
+		return err
 	}
+	d.Set("datasets", genericMap(datasets.Value, func(dataset powerbiapi.GetDatasetsInGroupResponseItem) map[string]interface{} {
+		return map[string]interface{}{
+			"id":             dataset.ID,
+			"name":           dataset.Name,
+			"configured_by":  dataset.ConfiguredBy,
+			"is_refreshable": dataset.IsRefreshable,
+		}
+	}))
+
+	reports, err := client.GetReportsInGroup(workspace.ID)
+	if err != nil {
+		return err
+	}
+	d.Set("reports", genericMap(reports.Value, func(report powerbiapi.GetReportsInGroupResponseItem) map[string]interface{} {
+		return map[string]interface{}{
+			"id":         report.ID,
+			"name":       report.Name,
+			"dataset_id": report.DatasetID,
+			"web_url":    report.WebURL,
+		}
+	}))
 
 	return nil
 }
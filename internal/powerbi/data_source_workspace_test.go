@@ -2,6 +2,7 @@ package powerbi
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
@@ -36,8 +37,30 @@ func TestAccDataSourceWorkspace_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("data.powerbi_workspace.test", "name", workspaceName),
 					resource.TestCheckResourceAttr("data.powerbi_workspace.test", "id", workspaceID),
+					resource.TestCheckResourceAttrSet("data.powerbi_workspace.test", "users.#"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace.test", "datasets.#", "0"),
+					resource.TestCheckResourceAttr("data.powerbi_workspace.test", "reports.#", "0"),
 				),
 			},
+			{
+				Config: fmt.Sprintf(`
+				data "powerbi_workspace" "test" {
+					id = "%s"
+				}
+				`, workspaceID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspace.test", "name", workspaceName),
+					resource.TestCheckResourceAttr("data.powerbi_workspace.test", "id", workspaceID),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				data "powerbi_workspace" "test" {
+					name = "%s - does not exist"
+				}
+				`, workspaceName),
+				ExpectError: regexp.MustCompile("No workspace found"),
+			},
 		},
 	})
 }
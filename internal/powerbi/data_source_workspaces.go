@@ -0,0 +1,120 @@
+package powerbi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// DataSourceWorkspaces represents the Power BI workspaces the user has access to
+func DataSourceWorkspaces() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceWorkspacesRead,
+
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An OData filter passed to the Power BI API, for example `contains(name,'Finance')`.",
+			},
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "If set, only workspaces with a name starting with this prefix are returned.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "If set, only workspaces with a name matching this regular expression are returned.",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"on_dedicated_capacity": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "If set, only workspaces that are (or are not) on dedicated capacity are returned.",
+			},
+			"workspaces": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The workspaces matching the filters.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The workspace ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The workspace name.",
+						},
+						"capacity_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The capacity ID the workspace is assigned to. Empty if the workspace is not on dedicated capacity.",
+						},
+						"is_on_dedicated_capacity": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the workspace is assigned to a dedicated capacity.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceWorkspacesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groups, err := client.GetGroups(d.Get("filter").(string), -1, 0)
+	if err != nil {
+		return err
+	}
+
+	namePrefix := d.Get("name_prefix").(string)
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	onDedicatedCapacity, onDedicatedCapacityOk := d.GetOkExists("on_dedicated_capacity")
+
+	ids := make([]string, 0)
+	workspaces := make([]map[string]interface{}, 0)
+	for _, group := range groups.Value {
+		if !strings.HasPrefix(group.Name, namePrefix) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(group.Name) {
+			continue
+		}
+		if onDedicatedCapacityOk && group.IsOnDedicatedCapacity != onDedicatedCapacity.(bool) {
+			continue
+		}
+
+		capacityID := ""
+		if group.IsOnDedicatedCapacity {
+			capacityID = group.CapacityID
+		}
+
+		ids = append(ids, group.ID)
+		workspaces = append(workspaces, map[string]interface{}{
+			"id":                       group.ID,
+			"name":                     group.Name,
+			"capacity_id":              capacityID,
+			"is_on_dedicated_capacity": group.IsOnDedicatedCapacity,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(strings.Join(ids, ","))))
+	d.Set("workspaces", workspaces)
+
+	return nil
+}
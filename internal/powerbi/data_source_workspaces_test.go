@@ -0,0 +1,62 @@
+package powerbi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceWorkspaces_basic(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+	workspacePrefix := fmt.Sprintf("Acceptance Test Data Source Workspaces %s", workspaceSuffix)
+
+	workspacesConfig := fmt.Sprintf(`
+	resource "powerbi_workspace" "test1" {
+		name = "%s - 1"
+	}
+
+	resource "powerbi_workspace" "test2" {
+		name = "%s - 2"
+	}
+	`, workspacePrefix, workspacePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step creates the workspaces to look up
+			{
+				Config: workspacesConfig,
+			},
+			// second step looks up workspaces by prefix
+			{
+				Config: workspacesConfig + fmt.Sprintf(`
+				data "powerbi_workspaces" "test" {
+					name_prefix = "%s"
+				}
+				`, workspacePrefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspaces.test", "workspaces.#", "2"),
+					resource.TestCheckResourceAttrSet("data.powerbi_workspaces.test", "workspaces.0.id"),
+				),
+			},
+			// third step combines an OData filter and a regex
+			{
+				Config: workspacesConfig + fmt.Sprintf(`
+				data "powerbi_workspaces" "test" {
+					filter     = "contains(name,'%s')"
+					name_regex = "- 2$"
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_workspaces.test", "workspaces.#", "1"),
+					resource.TestCheckResourceAttrPair("data.powerbi_workspaces.test", "workspaces.0.id", "powerbi_workspace.test2", "id"),
+					resource.TestCheckResourceAttr("data.powerbi_workspaces.test", "workspaces.0.capacity_id", ""),
+				),
+			},
+		},
+	})
+}
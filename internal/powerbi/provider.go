@@ -56,10 +56,19 @@ func Provider() *schema.Provider {
 			"powerbi_refresh_schedule": ResourceRefreshSchedule(),
 			"powerbi_workspace_access": ResourceGroupUsers(),
 			"powerbi_dataset":          ResourceDataset(),
+			"powerbi_paginated_report": ResourcePaginatedReport(),
+			"powerbi_pbip":             ResourcePBIP(),
+			"powerbi_report":           ResourceReport(),
+			"powerbi_report_clone":     ResourceReportClone(),
+			"powerbi_report_content":   ResourceReportContent(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"powerbi_workspace": DataSourceWorkspace(),
+			"powerbi_workspace":  DataSourceWorkspace(),
+			"powerbi_workspaces": DataSourceWorkspaces(),
+			"powerbi_capacities": DataSourceCapacities(),
+			"powerbi_capacity":   DataSourceCapacity(),
+			"powerbi_pbix_file":  DataSourcePBIXFile(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -0,0 +1,206 @@
+package powerbi
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// ResourcePaginatedReport represents a Power BI paginated report uploaded from an RDL file
+func ResourcePaginatedReport() *schema.Resource {
+	return &schema.Resource{
+		Create: createPaginatedReport,
+		Read:   readPaginatedReport,
+		Update: updatePaginatedReport,
+		Delete: deletePaginatedReport,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: customizeDiffSourceContentHash,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the paginated report will be added.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the paginated report. The `.rdl` extension is added when uploading if it is not present.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Description: "An absolute path to an RDL file on the local system.",
+				Required:    true,
+			},
+			"source_hash": {
+				Type:        schema.TypeString,
+				Description: "Used to trigger updates. If set, this value is used instead of the content hash calculated from `source`.",
+				Optional:    true,
+			},
+			"source_content_hash": {
+				Type:        schema.TypeString,
+				Description: "The MD5 hash of the RDL content, calculated during plan. A change in this value will reupload the RDL. If `source_hash` is set it is used as the value instead.",
+				Computed:    true,
+			},
+			"name_conflict": {
+				Type:         schema.TypeString,
+				Description:  "What to do if a paginated report with the same name already exists when it is first created. Any value from `Abort` or `Overwrite`. Subsequent uploads always overwrite the report managed by this resource.",
+				Optional:     true,
+				Default:      "Abort",
+				ValidateFunc: validation.StringInSlice([]string{"Abort", "Overwrite"}, false),
+			},
+			"report_id": {
+				Type:        schema.TypeString,
+				Description: "The ID for the paginated report.",
+				Computed:    true,
+			},
+			"web_url": {
+				Type:        schema.TypeString,
+				Description: "The web URL of the paginated report.",
+				Computed:    true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func createPaginatedReport(d *schema.ResourceData, meta interface{}) error {
+
+	err := createPaginatedReportImport(d, meta, d.Get("name_conflict").(string))
+	if err != nil {
+		return err
+	}
+
+	return readPaginatedReportImport(d, meta, d.Timeout(schema.TimeoutCreate))
+}
+
+func readPaginatedReport(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	err := readPaginatedReportImport(d, meta, d.Timeout(schema.TimeoutRead))
+	if isHTTP404Error(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// a report deleted through the portal is recreated, as there is nothing left for a reupload to overwrite
+	if reportID, ok := d.GetOk("report_id"); ok {
+		_, err := client.GetReportInGroup(d.Get("workspace_id").(string), reportID.(string))
+		if isHTTP404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func updatePaginatedReport(d *schema.ResourceData, meta interface{}) error {
+
+	if d.HasChange("source") || d.HasChange("source_hash") || d.HasChange("source_content_hash") {
+		err := createPaginatedReportImport(d, meta, "Overwrite")
+		if err != nil {
+			return err
+		}
+
+		return readPaginatedReportImport(d, meta, d.Timeout(schema.TimeoutUpdate))
+	}
+
+	return nil
+}
+
+func deletePaginatedReport(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	reportID, reportIDOk := d.GetOk("report_id")
+	if !reportIDOk {
+		return nil
+	}
+
+	// the report may have already been deleted outside of terraform
+	err := client.DeleteReportInGroup(d.Get("workspace_id").(string), reportID.(string))
+	if err != nil && !isHTTP404Error(err) {
+		return err
+	}
+	return nil
+}
+
+// paginatedReportDisplayName returns the display name for an RDL import. The API requires the file extension.
+func paginatedReportDisplayName(name string) string {
+	if strings.HasSuffix(strings.ToLower(name), ".rdl") {
+		return name
+	}
+	return name + ".rdl"
+}
+
+func createPaginatedReportImport(d *schema.ResourceData, meta interface{}, nameConflict string) error {
+	client := meta.(*powerbiapi.Client)
+
+	reader, err := os.Open(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	resp, err := client.PostImportInGroup(
+		d.Get("workspace_id").(string),
+		paginatedReportDisplayName(d.Get("name").(string)),
+		nameConflict,
+		false,
+		reader,
+	)
+	if err != nil {
+		return err
+	}
+
+	contentHash := d.Get("source_hash").(string)
+	if contentHash == "" {
+		contentHash, err = calculateContentHash(d.Get("source").(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId(resp.ID)
+	d.Set("source_content_hash", contentHash)
+
+	return nil
+}
+
+func readPaginatedReportImport(d *schema.ResourceData, meta interface{}, timeoutForSuccessfulImport time.Duration) error {
+	client := meta.(*powerbiapi.Client)
+
+	im, err := client.WaitForImportInGroupToSucceed(d.Get("workspace_id").(string), d.Id(), timeoutForSuccessfulImport)
+	if err != nil {
+		return err
+	}
+
+	// the import name includes the extension, which is only kept in state if it was configured
+	if paginatedReportDisplayName(d.Get("name").(string)) != im.Name {
+		d.Set("name", strings.TrimSuffix(im.Name, ".rdl"))
+	}
+
+	if len(im.Reports) >= 1 {
+		d.Set("report_id", im.Reports[0].ID)
+		d.Set("web_url", im.Reports[0].WebURL)
+	}
+
+	return nil
+}
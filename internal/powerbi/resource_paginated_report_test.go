@@ -0,0 +1,108 @@
+package powerbi
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccPaginatedReport_basic(t *testing.T) {
+	var reportID string
+	var groupID string
+	rdlLocation := TempFileName("", ".rdl")
+	rdlLocationTfFriendly := strings.ReplaceAll(rdlLocation, "\\", "\\\\")
+	workspaceSuffix := acctest.RandString(6)
+	isPremiumCapacity := os.Getenv("POWERBI_IS_PREMIUM")
+	premiumCapacityID := os.Getenv("POWERBI_CAPACITY_ID")
+
+	config := fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+		capacity_id = "%s"
+	}
+
+	resource "powerbi_paginated_report" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test Paginated Report"
+		source = "%s"
+	}
+	`, workspaceSuffix, premiumCapacityID, rdlLocationTfFriendly)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if strings.ToLower(isPremiumCapacity) != "true" {
+				t.Skip("Paginated report acceptance tests require POWERBI_IS_PREMIUM to be \"true\"")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step creates the resource
+			{
+				PreConfig: func() {
+					Copy("./resource_paginated_report_test_sample.rdl", rdlLocation)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test Paginated Report"),
+					resource.TestCheckResourceAttrSet("powerbi_paginated_report.test", "report_id"),
+					resource.TestCheckResourceAttr("powerbi_paginated_report.test", "name", "Acceptance Test Paginated Report"),
+					set("powerbi_paginated_report.test", "report_id", &reportID),
+					set("powerbi_workspace.test", "id", &groupID),
+				),
+			},
+			// second step deletes the report outside of terraform, which should be recreated
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*powerbiapi.Client)
+					client.DeleteReportInGroup(groupID, reportID)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test Paginated Report"),
+					testCheckResourceAttrNotEquals("powerbi_paginated_report.test", "report_id", &reportID),
+				),
+			},
+			// deletes the resource
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+					capacity_id = "%s"
+				}
+				`, workspaceSuffix, premiumCapacityID),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckReportDoesNotExistsInWorkspace("powerbi_workspace.test", "Acceptance Test Paginated Report"),
+					testCheckResourceRemoved("powerbi_paginated_report.test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPaginatedReport_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerbi_paginated_report" "test" {
+					workspace_id = "validation-should-fail-before-using-this"
+					name = "validation-should-fail-before-using-this"
+					source = "validation-should-fail-before-using-this"
+					name_conflict = "CreateOrOverwrite"
+				}
+				`,
+				ExpectError: regexp.MustCompile("config is invalid:.*name_conflict.*"),
+			},
+		},
+	})
+}
@@ -0,0 +1,311 @@
+package powerbi
+
+import (
+	"encoding/base64"
+	"os"
+	"time"
+
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/pbip"
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ResourcePBIP represents a Power BI Project (PBIP) folder deployed as a semantic model and / or report
+func ResourcePBIP() *schema.Resource {
+	return &schema.Resource{
+		Create: createPBIP,
+		Read:   readPBIP,
+		Update: updatePBIP,
+		Delete: deletePBIP,
+
+		CustomizeDiff: customizeDiffSourceFolderContentHash,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the project will be deployed.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the semantic model and report. Changing this value renames them in place.",
+				Required:    true,
+			},
+			"source_folder": {
+				Type:        schema.TypeString,
+				Description: "A path to a Power BI Project folder on the local system. The folder must contain a `*.SemanticModel` folder (TMDL or `model.bim`), a `*.Report` folder (PBIR), or both.",
+				Required:    true,
+			},
+			"source_folder_content_hash": {
+				Type:        schema.TypeString,
+				Description: "The MD5 hash of the definition files within `source_folder`, calculated during plan. A change in this value will update the definitions of the semantic model and report.",
+				Computed:    true,
+			},
+			"dataset_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the semantic model deployed from the project. Empty if the project does not contain a semantic model.",
+				Computed:    true,
+			},
+			"report_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the report deployed from the project. Empty if the project does not contain a report.",
+				Computed:    true,
+			},
+			"report_web_url": {
+				Type:        schema.TypeString,
+				Description: "The web URL of the report.",
+				Computed:    true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func customizeDiffSourceFolderContentHash(d *schema.ResourceDiff, meta interface{}) error {
+
+	// the folder may only be known after other resources are applied
+	if !d.NewValueKnown("source_folder") {
+		return d.SetNewComputed("source_folder_content_hash")
+	}
+
+	project, err := pbip.OpenProject(d.Get("source_folder").(string))
+	if os.IsNotExist(err) {
+		// the folder may be generated as part of the apply
+		return d.SetNewComputed("source_folder_content_hash")
+	}
+	if err != nil {
+		return err
+	}
+
+	contentHash, err := project.ContentHash()
+	if err != nil {
+		return err
+	}
+
+	if contentHash != d.Get("source_folder_content_hash").(string) {
+		return d.SetNew("source_folder_content_hash", contentHash)
+	}
+	return nil
+}
+
+func createPBIP(d *schema.ResourceData, meta interface{}) error {
+
+	err := deployPBIP(d, meta, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+
+	return readPBIP(d, meta)
+}
+
+func readPBIP(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+	reportID := d.Get("report_id").(string)
+
+	// a semantic model or report renamed through the portal is renamed back on the next apply
+	var names []string
+
+	datasetMissing := false
+	if datasetID != "" {
+		dataset, err := client.GetDatasetInGroup(groupID, datasetID)
+		if isHTTP404Error(err) {
+			datasetMissing = true
+		} else if err != nil {
+			return err
+		} else {
+			names = append(names, dataset.Name)
+		}
+	}
+
+	reportMissing := false
+	if reportID != "" {
+		report, err := client.GetReportInGroup(groupID, reportID)
+		if isHTTP404Error(err) {
+			reportMissing = true
+		} else if err != nil {
+			return err
+		} else {
+			names = append(names, report.Name)
+			d.Set("report_web_url", report.WebURL)
+		}
+	}
+
+	for _, name := range names {
+		if name != d.Get("name").(string) {
+			d.Set("name", name)
+			break
+		}
+	}
+
+	if (datasetID == "" || datasetMissing) && (reportID == "" || reportMissing) {
+		d.SetId("")
+		return nil
+	}
+
+	// a semantic model or report deleted through the portal is recreated on the next apply
+	if datasetMissing {
+		d.Set("dataset_id", "")
+		d.Set("source_folder_content_hash", "")
+	}
+	if reportMissing {
+		d.Set("report_id", "")
+		d.Set("source_folder_content_hash", "")
+	}
+
+	return nil
+}
+
+func updatePBIP(d *schema.ResourceData, meta interface{}) error {
+
+	if d.HasChange("name") {
+		err := renamePBIX(d, meta)
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("source_folder") || d.HasChange("source_folder_content_hash") {
+		err := deployPBIP(d, meta, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+	}
+
+	return readPBIP(d, meta)
+}
+
+func deletePBIP(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+
+	// the items may have already been deleted outside of terraform
+	if reportID, ok := d.GetOk("report_id"); ok {
+		err := client.DeleteReportInGroup(groupID, reportID.(string))
+		if err != nil && !isHTTP404Error(err) {
+			return err
+		}
+	}
+
+	if datasetID, ok := d.GetOk("dataset_id"); ok {
+		err := client.DeleteDatasetInGroup(groupID, datasetID.(string))
+		if err != nil && !isHTTP404Error(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deployPBIP creates the semantic model and report of the project, or updates their definitions if they already exist
+func deployPBIP(d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	name := d.Get("name").(string)
+
+	project, err := pbip.OpenProject(d.Get("source_folder").(string))
+	if err != nil {
+		return err
+	}
+
+	contentHash, err := project.ContentHash()
+	if err != nil {
+		return err
+	}
+
+	d.Partial(true)
+
+	semanticModelParts, err := project.SemanticModelParts()
+	if err != nil {
+		return err
+	}
+	datasetID := d.Get("dataset_id").(string)
+	if len(semanticModelParts) > 0 {
+		definition := buildItemDefinition(semanticModelParts)
+		if datasetID == "" {
+			resp, err := client.CreateSemanticModelWithDefinition(groupID, powerbiapi.CreateItemWithDefinitionRequest{
+				DisplayName: name,
+				Definition:  definition,
+			}, timeout)
+			if err != nil {
+				return err
+			}
+			datasetID = resp.ID
+		} else {
+			err := client.UpdateSemanticModelDefinition(groupID, datasetID, powerbiapi.UpdateItemDefinitionRequest{
+				Definition: definition,
+			}, timeout)
+			if err != nil {
+				return err
+			}
+		}
+
+		if d.Id() == "" {
+			d.SetId(datasetID)
+		}
+		d.SetPartial("workspace_id")
+		d.SetPartial("name")
+		d.SetPartial("dataset_id")
+		d.Set("dataset_id", datasetID)
+	}
+
+	reportParts, err := project.ReportParts(datasetID)
+	if err != nil {
+		return err
+	}
+	if len(reportParts) > 0 {
+		reportID := d.Get("report_id").(string)
+		definition := buildItemDefinition(reportParts)
+		if reportID == "" {
+			resp, err := client.CreateReportWithDefinition(groupID, powerbiapi.CreateItemWithDefinitionRequest{
+				DisplayName: name,
+				Definition:  definition,
+			}, timeout)
+			if err != nil {
+				return err
+			}
+			reportID = resp.ID
+		} else {
+			err := client.UpdateReportDefinition(groupID, reportID, powerbiapi.UpdateItemDefinitionRequest{
+				Definition: definition,
+			}, timeout)
+			if err != nil {
+				return err
+			}
+		}
+
+		if d.Id() == "" {
+			d.SetId(reportID)
+		}
+		d.SetPartial("workspace_id")
+		d.SetPartial("name")
+		d.SetPartial("report_id")
+		d.Set("report_id", reportID)
+	}
+
+	d.SetPartial("source_folder")
+	d.SetPartial("source_folder_content_hash")
+	d.Set("source_folder_content_hash", contentHash)
+	d.Partial(false)
+
+	return nil
+}
+
+func buildItemDefinition(parts []pbip.Part) powerbiapi.ItemDefinition {
+	return powerbiapi.ItemDefinition{
+		Parts: genericMap(parts, func(part pbip.Part) powerbiapi.ItemDefinitionPart {
+			return powerbiapi.ItemDefinitionPart{
+				Path:        part.Path,
+				Payload:     base64.StdEncoding.EncodeToString(part.Content),
+				PayloadType: "InlineBase64",
+			}
+		}).([]powerbiapi.ItemDefinitionPart),
+	}
+}
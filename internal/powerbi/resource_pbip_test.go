@@ -0,0 +1,122 @@
+package powerbi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccPBIP_basic(t *testing.T) {
+	var datasetID string
+	var reportID string
+	var groupID string
+	projectLocation := TempFileName("", "")
+	projectLocationTfFriendly := strings.ReplaceAll(projectLocation, "\\", "\\\\")
+	workspaceSuffix := acctest.RandString(6)
+
+	config := func(name string) string {
+		return fmt.Sprintf(`
+		resource "powerbi_workspace" "test" {
+			name = "Acceptance Test Workspace %s"
+		}
+
+		resource "powerbi_pbip" "test" {
+			workspace_id = "${powerbi_workspace.test.id}"
+			name = "%s"
+			source_folder = "%s"
+		}
+		`, workspaceSuffix, name, projectLocationTfFriendly)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step deploys the semantic model and report
+			{
+				PreConfig: func() {
+					copyDir("./resource_pbip_test_sample", projectLocation)
+				},
+				Config: config("Acceptance Test PBIP"),
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbip.test", "dataset_id", &datasetID),
+					set("powerbi_pbip.test", "report_id", &reportID),
+					set("powerbi_workspace.test", "id", &groupID),
+					resource.TestCheckResourceAttrSet("powerbi_pbip.test", "source_folder_content_hash"),
+					testCheckDatasetExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIP"),
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIP"),
+					testCheckReportDataset("powerbi_pbip.test", &datasetID),
+				),
+			},
+			// second step changes the model definition, which should update it in place
+			{
+				PreConfig: func() {
+					tablePath := filepath.Join(projectLocation, "Sample.SemanticModel", "definition", "tables", "Names.tmdl")
+					content, _ := ioutil.ReadFile(tablePath)
+					ioutil.WriteFile(tablePath, []byte(strings.ReplaceAll(string(content), `{"Bob"}`, `{"Bob"}, {"Carol"}`)), 0644)
+				},
+				Config: config("Acceptance Test PBIP"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("powerbi_pbip.test", "dataset_id", &datasetID),
+					resource.TestCheckResourceAttrPtr("powerbi_pbip.test", "report_id", &reportID),
+				),
+			},
+			// third step renames in place
+			{
+				Config: config("Acceptance Test PBIP - Renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("powerbi_pbip.test", "dataset_id", &datasetID),
+					resource.TestCheckResourceAttrPtr("powerbi_pbip.test", "report_id", &reportID),
+					testCheckDatasetExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIP - Renamed"),
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIP - Renamed"),
+				),
+			},
+			// fourth step renames the report outside of terraform, which should be renamed back
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*powerbiapi.Client)
+					err := client.UpdateReportNameInGroup(groupID, reportID, powerbiapi.UpdateReportNameInGroupRequest{
+						DisplayName: "Acceptance Test PBIP - Renamed Outside",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config("Acceptance Test PBIP - Renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("powerbi_pbip.test", "report_id", &reportID),
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIP - Renamed"),
+					testCheckReportDoesNotExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIP - Renamed Outside"),
+				),
+			},
+		},
+	})
+}
+
+// copyDir recursively copies the src folder to dst
+func copyDir(src string, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, relativePath)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		return Copy(path, target)
+	})
+}
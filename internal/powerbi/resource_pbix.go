@@ -1,18 +1,27 @@
 package powerbi
 
 import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/pbixrewriter"
 	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
 // ResourcePBIX represents a Power BI PBIX file
 func ResourcePBIX() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create: createPBIX,
 		Read:   readPBIX,
 		Update: updatePBIX,
@@ -21,6 +30,16 @@ func ResourcePBIX() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		// version 1 added source_content_hash
+		SchemaVersion: 1,
+
+		CustomizeDiff: customdiff.All(
+			customizeDiffSourceContentHash,
+			customizeDiffPBIXParameters,
+			customizeDiffPBIXDatasources,
+			customizeDiffPBIXRebindDataset,
+		),
+
 		Schema: map[string]*schema.Schema{
 			"workspace_id": {
 				Type:        schema.TypeString,
@@ -30,9 +49,8 @@ func ResourcePBIX() *schema.Resource {
 			},
 			"name": {
 				Type:        schema.TypeString,
-				Description: "Name of the PBIX. This will be used as the name for the report and dataset.",
+				Description: "Name of the PBIX. This will be used as the name for the report and dataset. Changing this value renames the report and dataset in place, keeping their IDs.",
 				Required:    true,
-				ForceNew:    true,
 			},
 			"source": {
 				Type:        schema.TypeString,
@@ -41,9 +59,21 @@ func ResourcePBIX() *schema.Resource {
 			},
 			"source_hash": {
 				Type:        schema.TypeString,
-				Description: "Used to trigger updates. The only meaningful value is `${filemd5(\"path/to/file\")}`.",
+				Description: "Used to trigger updates. If set, this value is used instead of the content hash calculated from `source`.",
 				Optional:    true,
 			},
+			"source_content_hash": {
+				Type:        schema.TypeString,
+				Description: "The MD5 hash of the PBIX content, calculated during plan. A change in this value will reupload the PBIX. If `source_hash` is set it is used as the value instead. Set to `drifted` when the report or dataset was changed or deleted outside of Terraform.",
+				Computed:    true,
+			},
+			"name_conflict": {
+				Type:         schema.TypeString,
+				Description:  "What to do if a report or dataset with the same name already exists when the PBIX is first uploaded. Any value from `Abort`, `CreateOrOverwrite`, `GenerateUniqueName` or `Overwrite`. `CreateOrOverwrite` and `Overwrite` take over an existing report and dataset with the same name, even if they are not managed by Terraform. `GenerateUniqueName` uploads under a new name, which is recorded in `content_name`. Subsequent uploads of the PBIX always overwrite the report and dataset managed by this resource.",
+				Optional:     true,
+				Default:      "CreateOrOverwrite",
+				ValidateFunc: validation.StringInSlice([]string{"Abort", "CreateOrOverwrite", "GenerateUniqueName", "Overwrite"}, false),
+			},
 			"skip_report": {
 				Type:        schema.TypeBool,
 				Description: "If true, only the PBIX dataset is deployed.",
@@ -60,12 +90,196 @@ func ResourcePBIX() *schema.Resource {
 				Description: "The ID for the dataset that was deployed as part of the PBIX.",
 				Computed:    true,
 			},
+			"refresh_on_parameter_change": {
+				Type:        schema.TypeBool,
+				Description: "If true, the dataset is refreshed after parameters or datasources are updated. The apply waits for the refresh to complete and fails if the refresh fails.",
+				Optional:    true,
+				Default:     false,
+			},
+			"rewrite": {
+				Type:        schema.TypeList,
+				Description: "Rewrites applied to a temporary copy of the PBIX before it is uploaded. The source file is not modified. Changing this value will require reuploading the PBIX.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dataset_id": {
+							Type:        schema.TypeString,
+							Description: "If set, repoints the live connection of a thin report to the specified dataset ID. This also strips the security bindings.",
+							Optional:    true,
+						},
+						"strip_security_bindings": {
+							Type:        schema.TypeBool,
+							Description: "If true, removes the security bindings from the PBIX. Otherwise the PBIX may appear corrupt when opened on the machine it was last saved on.",
+							Optional:    true,
+							Default:     false,
+						},
+						"page": {
+							Type:        schema.TypeList,
+							Description: "Pages of the report to hide or show.",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Description: "The name or display name of the page.",
+										Required:    true,
+									},
+									"hidden": {
+										Type:        schema.TypeBool,
+										Description: "Whether the page is hidden.",
+										Required:    true,
+									},
+								},
+							},
+						},
+						"active_page": {
+							Type:        schema.TypeString,
+							Description: "The name or display name of the page the report opens on.",
+							Optional:    true,
+						},
+						"theme": {
+							Type:        schema.TypeList,
+							Description: "Themes to replace within the static resources of the report. The upload fails if a theme does not exist in the report.",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Description: "The file name of the theme. For example `CY20SU09.json`.",
+										Required:    true,
+									},
+									"content": {
+										Type:         schema.TypeString,
+										Description:  "The theme JSON.",
+										Required:     true,
+										ValidateFunc: validation.StringIsJSON,
+									},
+								},
+							},
+						},
+						"image": {
+							Type:        schema.TypeList,
+							Description: "Images to replace within the static resources of the report, such as a logo. The upload fails if an image does not exist in the report.",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Description: "The file name of the image, or its path relative to `Report/StaticResources`.",
+										Required:    true,
+									},
+									"content_base64": {
+										Type:         schema.TypeString,
+										Description:  "The base64 encoded image. For example using `filebase64(\"logo.png\")`.",
+										Required:     true,
+										ValidateFunc: validation.StringIsBase64,
+									},
+								},
+							},
+						},
+						"connection_string": {
+							Type:        schema.TypeList,
+							Description: "Connection strings to replace within the connections of the PBIX.",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"original": {
+										Type:        schema.TypeString,
+										Description: "The connection string as configured in the PBIX.",
+										Required:    true,
+									},
+									"replacement": {
+										Type:        schema.TypeString,
+										Description: "The connection string replacing the original.",
+										Required:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"rebind_dataset_id": {
 				Type:          schema.TypeString,
 				Description:   "If set, will rebind the report to the the specified dataset ID.",
 				Optional:      true,
 				ConflictsWith: []string{"parameter", "datasource"},
 			},
+			"rebind_dataset_workspace_id": {
+				Type:         schema.TypeString,
+				Description:  "The workspace ID of the dataset specified in `rebind_dataset_id`, if it is in a different workspace to the report. During plan the dataset must exist and the caller must have Build permission on it, which is granted through the Admin, Member or Contributor role in that workspace. Defaults to `workspace_id`.",
+				Optional:     true,
+				RequiredWith: []string{"rebind_dataset_id"},
+			},
+			"reports": {
+				Type:        schema.TypeList,
+				Description: "All reports that were deployed as part of the PBIX.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The report ID.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The report name.",
+							Computed:    true,
+						},
+						"report_type": {
+							Type:        schema.TypeString,
+							Description: "The report type. Either `PowerBIReport` or `PaginatedReport`.",
+							Computed:    true,
+						},
+						"web_url": {
+							Type:        schema.TypeString,
+							Description: "The web URL of the report.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"datasets": {
+				Type:        schema.TypeList,
+				Description: "All datasets that were deployed as part of the PBIX.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The dataset ID.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The dataset name.",
+							Computed:    true,
+						},
+						"target_storage_mode": {
+							Type:        schema.TypeString,
+							Description: "The dataset storage mode.",
+							Computed:    true,
+						},
+						"web_url": {
+							Type:        schema.TypeString,
+							Description: "The web URL of the dataset.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"content_name": {
+				Type:        schema.TypeString,
+				Description: "The name the service gave the report and dataset when the PBIX was uploaded. This differs from `name` when `name_conflict` is `GenerateUniqueName` and `name` was already in use. Subsequent uploads of the PBIX use this name.",
+				Computed:    true,
+			},
+			"import_updated_time": {
+				Type:        schema.TypeString,
+				Description: "The time the PBIX content was last uploaded by Terraform. If the report or dataset is republished outside of Terraform after this time, the PBIX will be reuploaded.",
+				Computed:    true,
+			},
 			"report_original_dataset_id": {
 				Type:        schema.TypeString,
 				Description: "The dataset to which the report that was deployed is pointing. This is primarily used to allow reverting rebinded datasets back to the original source.",
@@ -73,7 +287,7 @@ func ResourcePBIX() *schema.Resource {
 			},
 			"parameter": {
 				Type:        schema.TypeSet,
-				Description: "Parameters to be configured on the PBIX dataset. These can be updated without requiring reuploading the PBIX. Any parameters not mentioned will not be tracked or updated",
+				Description: "Parameters to be configured on the PBIX dataset. These can be updated without requiring reuploading the PBIX. Values are validated against the parameter type, and every parameter must exist in the dataset. Any parameters not mentioned will not be tracked or updated",
 				Optional:    true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -90,9 +304,38 @@ func ResourcePBIX() *schema.Resource {
 					},
 				},
 			},
+			"all_parameters": {
+				Type:        schema.TypeList,
+				Description: "All parameters exposed by the PBIX dataset.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The parameter name",
+							Computed:    true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Description: "The parameter type. For example `Text`, `Number`, `Logical`, `Date` or `DateTime`",
+							Computed:    true,
+						},
+						"is_required": {
+							Type:        schema.TypeBool,
+							Description: "Whether the parameter requires a value",
+							Computed:    true,
+						},
+						"current_value": {
+							Type:        schema.TypeString,
+							Description: "The current value of the parameter",
+							Computed:    true,
+						},
+					},
+				},
+			},
 			"datasource": {
 				Type:        schema.TypeSet,
-				Description: "Datasources to be reconfigured after deploying the PBIX dataset. Changing this value will require reuploading the PBIX. Any datasource updated will not be tracked",
+				Description: "Datasources to be reconfigured after deploying the PBIX dataset. Changing this value will require reuploading the PBIX. A datasource that no longer matches any datasource in `current_datasources` is reported as drift and reapplied",
 				Optional:    true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -116,6 +359,41 @@ func ResourcePBIX() *schema.Resource {
 							Description: "The service URL, if applicable for the type of datasource",
 							Optional:    true,
 						},
+						"path": {
+							Type:        schema.TypeString,
+							Description: "The file or folder path, if applicable for the type of datasource. For example File, Folder",
+							Optional:    true,
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Description: "The connection kind, if applicable for the type of datasource",
+							Optional:    true,
+						},
+						"account": {
+							Type:        schema.TypeString,
+							Description: "The storage account name, if applicable for the type of datasource",
+							Optional:    true,
+						},
+						"domain": {
+							Type:        schema.TypeString,
+							Description: "The domain, if applicable for the type of datasource",
+							Optional:    true,
+						},
+						"email_address": {
+							Type:        schema.TypeString,
+							Description: "The email address, if applicable for the type of datasource",
+							Optional:    true,
+						},
+						"login_server": {
+							Type:        schema.TypeString,
+							Description: "The login server, if applicable for the type of datasource",
+							Optional:    true,
+						},
+						"class_info": {
+							Type:        schema.TypeString,
+							Description: "The connector class information, if applicable for Extension datasources such as custom connectors",
+							Optional:    true,
+						},
 						"original_database": {
 							Type:        schema.TypeString,
 							Description: "The database name as configured in the PBIX, if applicable for the type of datasource This will be the value replaced with the value in the 'databsase' field",
@@ -131,6 +409,125 @@ func ResourcePBIX() *schema.Resource {
 							Description: "The service URL as configured in the PBIX, if applicable for the type of datasource. This will be the value replaced with the value in the 'url' field",
 							Optional:    true,
 						},
+						"original_path": {
+							Type:        schema.TypeString,
+							Description: "The file or folder path as configured in the PBIX, if applicable for the type of datasource. This will be the value replaced with the value in the 'path' field",
+							Optional:    true,
+						},
+						"original_kind": {
+							Type:        schema.TypeString,
+							Description: "The connection kind as configured in the PBIX, if applicable for the type of datasource. This will be the value replaced with the value in the 'kind' field",
+							Optional:    true,
+						},
+						"original_account": {
+							Type:        schema.TypeString,
+							Description: "The storage account name as configured in the PBIX, if applicable for the type of datasource. This will be the value replaced with the value in the 'account' field",
+							Optional:    true,
+						},
+						"original_domain": {
+							Type:        schema.TypeString,
+							Description: "The domain as configured in the PBIX, if applicable for the type of datasource. This will be the value replaced with the value in the 'domain' field",
+							Optional:    true,
+						},
+						"original_email_address": {
+							Type:        schema.TypeString,
+							Description: "The email address as configured in the PBIX, if applicable for the type of datasource. This will be the value replaced with the value in the 'email_address' field",
+							Optional:    true,
+						},
+						"original_login_server": {
+							Type:        schema.TypeString,
+							Description: "The login server as configured in the PBIX, if applicable for the type of datasource. This will be the value replaced with the value in the 'login_server' field",
+							Optional:    true,
+						},
+						"original_class_info": {
+							Type:        schema.TypeString,
+							Description: "The connector class information as configured in the PBIX, if applicable for the type of datasource. This will be the value replaced with the value in the 'class_info' field",
+							Optional:    true,
+						},
+						"datasource_id": {
+							Type:        schema.TypeString,
+							Description: "Selects the datasource to update by its ID instead of by its original connection details. Requires `gateway_id`",
+							Optional:    true,
+						},
+						"gateway_id": {
+							Type:        schema.TypeString,
+							Description: "The gateway ID of the datasource selected by `datasource_id`",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"current_datasources": {
+				Type:        schema.TypeList,
+				Description: "All datasources currently used by the PBIX dataset.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Description: "The type of datasource",
+							Computed:    true,
+						},
+						"database": {
+							Type:        schema.TypeString,
+							Description: "The database name, if applicable for the type of datasource",
+							Computed:    true,
+						},
+						"server": {
+							Type:        schema.TypeString,
+							Description: "The server name, if applicable for the type of datasource",
+							Computed:    true,
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Description: "The service URL, if applicable for the type of datasource",
+							Computed:    true,
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Description: "The file or folder path, if applicable for the type of datasource. For example File, Folder",
+							Computed:    true,
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Description: "The connection kind, if applicable for the type of datasource",
+							Computed:    true,
+						},
+						"account": {
+							Type:        schema.TypeString,
+							Description: "The storage account name, if applicable for the type of datasource",
+							Computed:    true,
+						},
+						"domain": {
+							Type:        schema.TypeString,
+							Description: "The domain, if applicable for the type of datasource",
+							Computed:    true,
+						},
+						"email_address": {
+							Type:        schema.TypeString,
+							Description: "The email address, if applicable for the type of datasource",
+							Computed:    true,
+						},
+						"login_server": {
+							Type:        schema.TypeString,
+							Description: "The login server, if applicable for the type of datasource",
+							Computed:    true,
+						},
+						"class_info": {
+							Type:        schema.TypeString,
+							Description: "The connector class information, if applicable for Extension datasources such as custom connectors",
+							Computed:    true,
+						},
+						"gateway_id": {
+							Type:        schema.TypeString,
+							Description: "The bound gateway ID. Empty when not bound to a gateway",
+							Computed:    true,
+						},
+						"datasource_id": {
+							Type:        schema.TypeString,
+							Description: "The bound datasource ID. Empty when not bound to a gateway",
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -139,18 +536,301 @@ func ResourcePBIX() *schema.Resource {
 			Default: schema.DefaultTimeout(5 * time.Minute),
 		},
 	}
+
+	// version 0 only lacks computed attributes, so its state can be decoded with the current schema
+	resource.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resource.CoreConfigSchema().ImpliedType(),
+			Upgrade: upgradePBIXStateV0,
+		},
+	}
+
+	return resource
+}
+
+// upgradePBIXStateV0 calculates the source_content_hash missing from version 0 state.
+// Otherwise the first plan after upgrading would see a new hash and reupload every PBIX
+func upgradePBIXStateV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+
+	if contentHash, _ := rawState["source_content_hash"].(string); contentHash != "" {
+		return rawState, nil
+	}
+
+	contentHash, _ := rawState["source_hash"].(string)
+	if contentHash == "" {
+		source, _ := rawState["source"].(string)
+
+		var err error
+		contentHash, err = calculateContentHash(source)
+		if os.IsNotExist(err) {
+			// the file may be generated as part of the apply, in which case the plan reuploads it
+			return rawState, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rawState["source_content_hash"] = contentHash
+	return rawState, nil
 }
 
-func openContentReader(d *schema.ResourceData) (io.Reader, error) {
+func openContentReader(d *schema.ResourceData) (io.ReadCloser, error) {
 	filepath := d.Get("source").(string)
-	return os.Open(filepath)
+
+	pipelineFuncs, validateFuncs, err := buildPBIXRewritePipelineFuncs(d)
+	if err != nil {
+		return nil, err
+	}
+	if len(pipelineFuncs) == 0 {
+		return os.Open(filepath)
+	}
+
+	err = pbixrewriter.ValidatePbixFile(filepath, validateFuncs)
+	if err != nil {
+		return nil, err
+	}
+
+	return openRewrittenPBIX(filepath, pipelineFuncs)
+}
+
+// openRewrittenPBIX rewrites the PBIX into a temporary file, which is removed once the returned reader is closed
+func openRewrittenPBIX(filepath string, pipelineFuncs []pbixrewriter.PipelineFunc) (io.ReadCloser, error) {
+	tempFile, err := ioutil.TempFile("", "*.pbix")
+	if err != nil {
+		return nil, err
+	}
+	tempFile.Close()
+
+	err = pbixrewriter.RewritePbixFiles(filepath, tempFile.Name(), pipelineFuncs)
+	if err != nil {
+		os.Remove(tempFile.Name())
+		return nil, err
+	}
+
+	reader, err := os.Open(tempFile.Name())
+	if err != nil {
+		os.Remove(tempFile.Name())
+		return nil, err
+	}
+	return tempFileReadCloser{reader}, nil
+}
+
+// tempFileReadCloser removes the underlying file once it is closed
+type tempFileReadCloser struct {
+	*os.File
+}
+
+func (f tempFileReadCloser) Close() error {
+	err := f.File.Close()
+	os.Remove(f.File.Name())
+	return err
+}
+
+// buildPBIXRewritePipelineFuncs returns the PipelineFuncs for the rewrite block, and the ValidateFuncs checking that
+// the entries they rewrite exist in the PBIX
+func buildPBIXRewritePipelineFuncs(d *schema.ResourceData) ([]pbixrewriter.PipelineFunc, []pbixrewriter.ValidateFunc, error) {
+	var pipelineFuncs []pbixrewriter.PipelineFunc
+	var validateFuncs []pbixrewriter.ValidateFunc
+
+	rewriteList := d.Get("rewrite").([]interface{})
+	if len(rewriteList) == 0 || rewriteList[0] == nil {
+		return pipelineFuncs, validateFuncs, nil
+	}
+	rewriteObj := rewriteList[0].(map[string]interface{})
+
+	if datasetID := rewriteObj["dataset_id"].(string); datasetID != "" {
+		pipelineFuncs = append(pipelineFuncs, pbixrewriter.SetDatasetIDPipelineFunc(datasetID))
+	}
+	if rewriteObj["strip_security_bindings"].(bool) {
+		pipelineFuncs = append(pipelineFuncs, pbixrewriter.RemoveSecurityBindingsPipelineFunc())
+	}
+	var pageVisibilities []pbixrewriter.PageVisibility
+	for _, pageObj := range rewriteObj["page"].([]interface{}) {
+		pageObj := pageObj.(map[string]interface{})
+		pageVisibilities = append(pageVisibilities, pbixrewriter.PageVisibility{
+			Page:   pageObj["name"].(string),
+			Hidden: pageObj["hidden"].(bool),
+		})
+	}
+	if len(pageVisibilities) > 0 {
+		pipelineFuncs = append(pipelineFuncs, pbixrewriter.SetPageVisibilityPipelineFunc(pageVisibilities))
+		validateFuncs = append(validateFuncs, pbixrewriter.ReportLayoutExistsValidateFunc())
+	}
+	if activePage := rewriteObj["active_page"].(string); activePage != "" {
+		pipelineFuncs = append(pipelineFuncs, pbixrewriter.SetActivePagePipelineFunc(activePage))
+		validateFuncs = append(validateFuncs, pbixrewriter.ReportLayoutExistsValidateFunc())
+	}
+	for _, themeObj := range rewriteObj["theme"].([]interface{}) {
+		themeObj := themeObj.(map[string]interface{})
+		pipelineFuncs = append(pipelineFuncs, pbixrewriter.ReplaceThemePipelineFunc(themeObj["name"].(string), []byte(themeObj["content"].(string))))
+		validateFuncs = append(validateFuncs, pbixrewriter.StaticResourceExistsValidateFunc(themeObj["name"].(string)))
+	}
+	for _, imageObj := range rewriteObj["image"].([]interface{}) {
+		imageObj := imageObj.(map[string]interface{})
+		content, err := base64.StdEncoding.DecodeString(imageObj["content_base64"].(string))
+		if err != nil {
+			return nil, nil, err
+		}
+		pipelineFuncs = append(pipelineFuncs, pbixrewriter.ReplaceStaticResourcePipelineFunc(imageObj["name"].(string), content))
+		validateFuncs = append(validateFuncs, pbixrewriter.StaticResourceExistsValidateFunc(imageObj["name"].(string)))
+	}
+	for _, connectionStringObj := range rewriteObj["connection_string"].([]interface{}) {
+		connectionStringObj := connectionStringObj.(map[string]interface{})
+		pipelineFuncs = append(pipelineFuncs, pbixrewriter.ReplaceConnectionStringPipelineFunc(
+			connectionStringObj["original"].(string),
+			connectionStringObj["replacement"].(string),
+		))
+	}
+
+	return pipelineFuncs, validateFuncs, nil
+}
+
+func calculateContentHash(filepath string) (string, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// sourceContentHashDrifted is stored instead of the content hash when the content was changed outside of terraform.
+// It is never a valid hash, and is distinct from the empty value of content hashes that were never calculated
+const sourceContentHashDrifted = "drifted"
+
+func customizeDiffSourceContentHash(d *schema.ResourceDiff, meta interface{}) error {
+
+	// the source or its hash may only be known after other resources are applied
+	if !d.NewValueKnown("source") || !d.NewValueKnown("source_hash") {
+		return d.SetNewComputed("source_content_hash")
+	}
+
+	contentHash := d.Get("source_hash").(string)
+	if contentHash == "" {
+		var err error
+		contentHash, err = calculateContentHash(d.Get("source").(string))
+		if os.IsNotExist(err) {
+			// the file may be generated as part of the apply
+			return d.SetNewComputed("source_content_hash")
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// drifted content is reuploaded even when the source has not changed
+	previousContentHash := d.Get("source_content_hash").(string)
+	if previousContentHash == sourceContentHashDrifted || contentHash != previousContentHash {
+		return d.SetNew("source_content_hash", contentHash)
+	}
+	return nil
+}
+
+func customizeDiffPBIXParameters(d *schema.ResourceDiff, meta interface{}) error {
+
+	// parameters are only known after the PBIX is uploaded, and may change when it is reuploaded.
+	// In these cases parameters are validated when they are applied instead
+	allParameters := d.Get("all_parameters").([]interface{})
+	if len(allParameters) == 0 || d.HasChange("source_content_hash") || !d.NewValueKnown("parameter") {
+		return nil
+	}
+
+	parameterTypes := make(map[string]string)
+	for _, apiParameter := range allParameters {
+		apiParameterObj := apiParameter.(map[string]interface{})
+		parameterTypes[apiParameterObj["name"].(string)] = apiParameterObj["type"].(string)
+	}
+
+	return validatePBIXParameters(d.Get("parameter").(*schema.Set), parameterTypes)
+}
+
+func validatePBIXParameters(parameters *schema.Set, parameterTypes map[string]string) error {
+	for _, parameterObj := range parameters.List() {
+		parameterObj := parameterObj.(map[string]interface{})
+		name := parameterObj["name"].(string)
+		value := parameterObj["value"].(string)
+
+		parameterType, ok := parameterTypes[name]
+		if !ok {
+			return fmt.Errorf("Parameter '%s' does not exist in the PBIX dataset", name)
+		}
+
+		err := validatePBIXParameterValue(parameterType, value)
+		if err != nil {
+			return fmt.Errorf("Parameter '%s' has an invalid value: %v", name, err)
+		}
+	}
+	return nil
+}
+
+var pbixParameterDateLayouts = []string{
+	"2006-01-02",
+	"1/2/2006",
+}
+
+var pbixParameterDateTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"1/2/2006 15:04:05",
+	"1/2/2006 3:04:05 PM",
+}
+
+func validatePBIXParameterValue(parameterType string, value string) error {
+	switch parameterType {
+	case "Number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("'%s' is not a number", value)
+		}
+	case "Logical":
+		if !strings.EqualFold(value, "true") && !strings.EqualFold(value, "false") {
+			return fmt.Errorf("'%s' is not a logical value. Expected 'true' or 'false'", value)
+		}
+	case "Date":
+		if !matchesAnyTimeLayout(value, pbixParameterDateLayouts) {
+			return fmt.Errorf("'%s' is not a date. Expected a format such as '2006-01-02'", value)
+		}
+	case "DateTime", "DateTimeZone":
+		if !matchesAnyTimeLayout(value, append(pbixParameterDateTimeLayouts, pbixParameterDateLayouts...)) {
+			return fmt.Errorf("'%s' is not a date time. Expected a format such as '2006-01-02T15:04:05'", value)
+		}
+	}
+	return nil
+}
+
+func matchesAnyTimeLayout(value string, layouts []string) bool {
+	for _, layout := range layouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func customizeDiffPBIXDatasources(d *schema.ResourceDiff, meta interface{}) error {
+
+	// datasources are checked during plan so that nothing is uploaded when they are invalid
+	for _, datasourceObj := range d.Get("datasource").(*schema.Set).List() {
+		datasourceObj := datasourceObj.(map[string]interface{})
+		if datasourceObj["datasource_id"] != "" && datasourceObj["gateway_id"] == "" {
+			return fmt.Errorf("Datasource with datasource_id '%s' must also set gateway_id", datasourceObj["datasource_id"])
+		}
+	}
+	return nil
 }
 
 func createPBIX(d *schema.ResourceData, meta interface{}) error {
 
 	d.Partial(true)
 
-	err := createImport(d, meta)
+	err := createImport(d, meta, d.Get("name_conflict").(string))
 	if err != nil {
 		return err
 	}
@@ -170,6 +850,11 @@ func createPBIX(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	err = refreshPBIXDataset(d, meta, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+
 	if _, ok := d.GetOk("rebind_dataset_id"); ok {
 		err = rebindPBIXDataset(d, meta)
 		if err != nil {
@@ -194,6 +879,21 @@ func readPBIX(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	err = readPBIXContentDrift(d, meta)
+	if err != nil {
+		return err
+	}
+
+	// a report or dataset deleted through the portal is recreated by reuploading the PBIX
+	reportExists, datasetExists, err := readPBIXContentExists(d, meta)
+	if err != nil {
+		return err
+	}
+	if !reportExists || !datasetExists {
+		markPBIXContentDrifted(d)
+		return nil
+	}
+
 	err = readPBIXParameters(d, meta)
 	if err != nil {
 		return err
@@ -215,17 +915,34 @@ func updatePBIX(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	if d.HasChange("source") || d.HasChange("source_hash") || d.HasChange("datasource") {
+	// Rename first, so any reupload below overwrites the renamed report and dataset
+	if d.HasChange("name") {
+		err := renamePBIX(d, meta)
+		if err != nil {
+			return err
+		}
+
+		// later uploads use the new name
+		d.Set("content_name", d.Get("name").(string))
+	}
+
+	if d.HasChange("source") || d.HasChange("source_hash") || d.HasChange("source_content_hash") || d.HasChange("rewrite") || d.HasChange("datasource") {
 
 		d.Partial(true)
 
+		err := forgetMissingPBIXContent(d, meta)
+		if err != nil {
+			return err
+		}
+
 		// Imports do not update rebinded datasets, so we unbind before doing the import
-		err := unbindPBIXDataset(d, meta)
+		err = unbindPBIXDataset(d, meta)
 		if err != nil {
 			return err
 		}
 
-		err = createImport(d, meta)
+		// the report and dataset are owned by this resource, so reuploads always replace them
+		err = createImport(d, meta, "CreateOrOverwrite")
 		if err != nil {
 			return err
 		}
@@ -245,6 +962,11 @@ func updatePBIX(d *schema.ResourceData, meta interface{}) error {
 			return err
 		}
 
+		err = refreshPBIXDataset(d, meta, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+
 		err = rebindPBIXDataset(d, meta)
 		if err != nil {
 			return err
@@ -255,7 +977,7 @@ func updatePBIX(d *schema.ResourceData, meta interface{}) error {
 		return nil
 	}
 
-	if d.HasChange("rebind_dataset_id") {
+	if d.HasChange("rebind_dataset_id") || d.HasChange("rebind_dataset_workspace_id") {
 		err := unbindPBIXDataset(d, meta)
 		if err != nil {
 			return err
@@ -272,6 +994,11 @@ func updatePBIX(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return err
 		}
+
+		err = refreshPBIXDataset(d, meta, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -288,16 +1015,17 @@ func deletePBIX(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	// the report or dataset may have already been deleted outside of terraform
 	if reportID, reportIDOk := d.GetOk("report_id"); reportIDOk {
 		err := client.DeleteReportInGroup(groupID, reportID.(string))
-		if err != nil {
+		if err != nil && !isHTTP404Error(err) {
 			return err
 		}
 	}
 
 	if datasetID, datasetIDOk := d.GetOk("dataset_id"); datasetIDOk {
 		err := client.DeleteDatasetInGroup(groupID, datasetID.(string))
-		if err != nil {
+		if err != nil && !isHTTP404Error(err) {
 			return err
 		}
 	}
@@ -305,18 +1033,26 @@ func deletePBIX(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func createImport(d *schema.ResourceData, meta interface{}) error {
+func createImport(d *schema.ResourceData, meta interface{}, nameConflict string) error {
 	client := meta.(*powerbiapi.Client)
 
 	reader, err := openContentReader(d)
 	if err != nil {
 		return err
 	}
+	defer reader.Close()
+
+	// reuploads must use the name the service gave the content, otherwise
+	// other content with the configured name would be overwritten instead
+	name := d.Get("content_name").(string)
+	if name == "" {
+		name = d.Get("name").(string)
+	}
 
 	resp, err := client.PostImportInGroup(
 		d.Get("workspace_id").(string),
-		d.Get("name").(string),
-		"CreateOrOverwrite",
+		name,
+		nameConflict,
 		d.Get("skip_report").(bool),
 		reader,
 	)
@@ -324,10 +1060,24 @@ func createImport(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	contentHash := d.Get("source_hash").(string)
+	if contentHash == "" {
+		contentHash, err = calculateContentHash(d.Get("source").(string))
+		if err != nil {
+			return err
+		}
+	}
+
 	d.SetId(resp.ID)
 	d.SetPartial("workspace_id")
 	d.SetPartial("source")
 	d.SetPartial("source_hash")
+	d.SetPartial("source_content_hash")
+	d.SetPartial("rewrite")
+	d.Set("source_content_hash", contentHash)
+
+	// cleared so that readImport records the time of this upload
+	d.Set("import_updated_time", "")
 
 	return nil
 }
@@ -342,13 +1092,32 @@ func readImport(d *schema.ResourceData, meta interface{}, timeoutForSuccessfulIm
 		return err
 	}
 
-	d.SetPartial("name")
-	d.Set("name", im.Name)
+	// the import keeps the name it was uploaded with, even after the report and dataset
+	// are renamed. So it is only used to populate the name when importing existing imports
+	if d.Get("name").(string) == "" {
+		d.SetPartial("name")
+		d.Set("name", im.Name)
+	}
+
+	d.SetPartial("reports")
+	d.Set("reports", flattenImportReports(im.Reports))
+	d.SetPartial("datasets")
+	d.Set("datasets", flattenImportDatasets(im.Datasets))
+
+	if d.Get("import_updated_time").(string) == "" {
+		d.SetPartial("import_updated_time")
+		d.Set("import_updated_time", im.UpdatedDateTime.Format(time.RFC3339Nano))
+
+		// the service may have generated a unique name for the uploaded content
+		d.SetPartial("content_name")
+		d.Set("content_name", importContentName(im))
+	}
 
 	// powerbi imports can be modified by some operations (such as rebind)
 	// in order to keep reference to the original report and original dataset
-	// we will only look them up once after creation
-	if d.IsNewResource() {
+	// we will only look them up once after creation, or after they were
+	// deleted outside of terraform and have been reuploaded
+	if d.IsNewResource() || d.Get("report_id").(string) == "" {
 		if len(im.Reports) >= 1 {
 			d.SetPartial("report_id")
 			d.Set("report_id", im.Reports[0].ID)
@@ -360,7 +1129,9 @@ func readImport(d *schema.ResourceData, meta interface{}, timeoutForSuccessfulIm
 			d.SetPartial("report_original_dataset_id")
 			d.Set("report_original_dataset_id", report.DatasetID)
 		}
+	}
 
+	if d.IsNewResource() || d.Get("dataset_id").(string) == "" {
 		if len(im.Datasets) >= 1 {
 			d.SetPartial("dataset_id")
 			d.Set("dataset_id", im.Datasets[0].ID)
@@ -369,6 +1140,134 @@ func readImport(d *schema.ResourceData, meta interface{}, timeoutForSuccessfulIm
 	return nil
 }
 
+// importContentName returns the name of the content created by an import, preferring the dataset name
+func importContentName(im *powerbiapi.GetImportInGroupResponse) string {
+	if len(im.Datasets) >= 1 {
+		return im.Datasets[0].Name
+	}
+	if len(im.Reports) >= 1 {
+		return im.Reports[0].Name
+	}
+	return im.Name
+}
+
+func flattenImportReports(reports []powerbiapi.GetImportInGroupResponseReport) []map[string]interface{} {
+	return genericMap(reports, func(report powerbiapi.GetImportInGroupResponseReport) map[string]interface{} {
+		return map[string]interface{}{
+			"id":          report.ID,
+			"name":        report.Name,
+			"report_type": report.ReportType,
+			"web_url":     report.WebURL,
+		}
+	}).([]map[string]interface{})
+}
+
+func flattenImportDatasets(datasets []powerbiapi.GetImportInGroupResponseDataset) []map[string]interface{} {
+	return genericMap(datasets, func(dataset powerbiapi.GetImportInGroupResponseDataset) map[string]interface{} {
+		return map[string]interface{}{
+			"id":                  dataset.ID,
+			"name":                dataset.Name,
+			"target_storage_mode": dataset.TargetStorageMode,
+			"web_url":             dataset.WebURL,
+		}
+	}).([]map[string]interface{})
+}
+
+// readPBIXContentExists checks whether the report and dataset in state still exist in the workspace
+func readPBIXContentExists(d *schema.ResourceData, meta interface{}) (reportExists bool, datasetExists bool, err error) {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	reportExists = true
+	datasetExists = true
+
+	if reportID, reportOk := d.GetOk("report_id"); reportOk {
+		_, err = client.GetReportInGroup(groupID, reportID.(string))
+		if isHTTP404Error(err) {
+			reportExists = false
+		} else if err != nil {
+			return false, false, err
+		}
+	}
+
+	if datasetID, datasetOk := d.GetOk("dataset_id"); datasetOk {
+		_, err = client.GetDatasetInGroup(groupID, datasetID.(string))
+		if isHTTP404Error(err) {
+			datasetExists = false
+		} else if err != nil {
+			return false, false, err
+		}
+	}
+
+	return reportExists, datasetExists, nil
+}
+
+// forgetMissingPBIXContent removes references to a report or dataset that was deleted outside of terraform,
+// so that they are looked up again from the next import
+func forgetMissingPBIXContent(d *schema.ResourceData, meta interface{}) error {
+	reportExists, datasetExists, err := readPBIXContentExists(d, meta)
+	if err != nil {
+		return err
+	}
+
+	if !reportExists {
+		d.Set("report_id", "")
+		d.Set("report_original_dataset_id", "")
+	}
+	if !datasetExists {
+		d.Set("dataset_id", "")
+	}
+	return nil
+}
+
+func readPBIXContentDrift(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	reportID := d.Get("report_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+
+	importUpdatedTime, err := time.Parse(time.RFC3339Nano, d.Get("import_updated_time").(string))
+	if err != nil {
+		return err
+	}
+
+	// Publishing from Power BI Desktop creates a new import that overwrites our report and dataset.
+	// If any import containing them is newer than our upload, the content has drifted
+	imports, err := client.GetImportsInGroup(groupID)
+	if err != nil {
+		return err
+	}
+
+	for _, im := range imports.Value {
+		// our own import is excluded as the service may touch it when its content is updated by terraform
+		if strings.EqualFold(im.ID, d.Id()) || !im.UpdatedDateTime.After(importUpdatedTime) {
+			continue
+		}
+
+		containsPBIXContent := false
+		for _, report := range im.Reports {
+			containsPBIXContent = containsPBIXContent || (reportID != "" && report.ID == reportID)
+		}
+		for _, dataset := range im.Datasets {
+			containsPBIXContent = containsPBIXContent || (datasetID != "" && dataset.ID == datasetID)
+		}
+
+		if containsPBIXContent {
+			markPBIXContentDrifted(d)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// markPBIXContentDrifted replaces the content hash with sourceContentHashDrifted so that the next plan reuploads the PBIX
+func markPBIXContentDrifted(d *schema.ResourceData) {
+	d.SetPartial("source_content_hash")
+	d.Set("source_content_hash", sourceContentHashDrifted)
+}
+
 func setPBIXParameters(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*powerbiapi.Client)
@@ -383,6 +1282,19 @@ func setPBIXParameters(d *schema.ResourceData, meta interface{}) error {
 				return fmt.Errorf("Unable to update parameters on a PBIX file that does not contain a dataset")
 			}
 
+			apiParameters, err := client.GetParametersInGroup(groupID, datasetID.(string))
+			if err != nil {
+				return err
+			}
+			parameterTypes := make(map[string]string)
+			for _, apiParameter := range apiParameters.Value {
+				parameterTypes[apiParameter.Name] = apiParameter.Type
+			}
+			err = validatePBIXParameters(parameter, parameterTypes)
+			if err != nil {
+				return err
+			}
+
 			updateParameterRequest := powerbiapi.UpdateParametersInGroupRequest{}
 			for _, parameterObj := range parameterList {
 				parameterObj := parameterObj.(map[string]interface{})
@@ -392,7 +1304,7 @@ func setPBIXParameters(d *schema.ResourceData, meta interface{}) error {
 				})
 			}
 
-			err := client.UpdateParametersInGroup(groupID, datasetID.(string), updateParameterRequest)
+			err = client.UpdateParametersInGroup(groupID, datasetID.(string), updateParameterRequest)
 			if err != nil {
 				return err
 			}
@@ -433,6 +1345,16 @@ func readPBIXParameters(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetPartial("parameter")
 	d.Set("parameter", stateParameters)
+
+	d.SetPartial("all_parameters")
+	d.Set("all_parameters", genericMap(apiParameters.Value, func(apiParameter powerbiapi.GetParametersInGroupResponseItem) map[string]interface{} {
+		return map[string]interface{}{
+			"name":          apiParameter.Name,
+			"type":          apiParameter.Type,
+			"is_required":   apiParameter.IsRequired,
+			"current_value": apiParameter.CurrentValue,
+		}
+	}))
 	return nil
 }
 
@@ -455,18 +1377,12 @@ func setPBIXDatasources(d *schema.ResourceData, meta interface{}) error {
 			for _, datasourceObj := range datasourceList {
 				datasourceObj := datasourceObj.(map[string]interface{})
 				updateDatasourcesRequest.UpdateDetails = append(updateDatasourcesRequest.UpdateDetails, powerbiapi.UpdateDatasourcesInGroupRequestItem{
-					ConnectionDetails: powerbiapi.UpdateDatasourcesInGroupRequestItemConnectionDetails{
-						URL:      emptyStringToNil(datasourceObj["url"].(string)),
-						Database: emptyStringToNil(datasourceObj["database"].(string)),
-						Server:   emptyStringToNil(datasourceObj["server"].(string)),
-					},
+					ConnectionDetails: expandPBIXDatasourceConnectionDetails(datasourceObj, ""),
 					DatasourceSelector: powerbiapi.UpdateDatasourcesInGroupRequestItemDatasourceSelector{
-						DatasourceType: datasourceObj["type"].(string),
-						ConnectionDetails: powerbiapi.UpdateDatasourcesInGroupRequestItemConnectionDetails{
-							URL:      emptyStringToNil(datasourceObj["original_url"].(string)),
-							Database: emptyStringToNil(datasourceObj["original_database"].(string)),
-							Server:   emptyStringToNil(datasourceObj["original_server"].(string)),
-						},
+						DatasourceType:    datasourceObj["type"].(string),
+						ConnectionDetails: expandPBIXDatasourceConnectionDetails(datasourceObj, "original_"),
+						DatasourceID:      datasourceObj["datasource_id"].(string),
+						GatewayID:         datasourceObj["gateway_id"].(string),
 					},
 				})
 			}
@@ -483,6 +1399,26 @@ func setPBIXDatasources(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+func refreshPBIXDataset(d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+
+	if !d.Get("refresh_on_parameter_change").(bool) {
+		return nil
+	}
+
+	// only refresh when parameters or datasources were actually applied to the dataset
+	if d.Get("parameter").(*schema.Set).Len() == 0 && d.Get("datasource").(*schema.Set).Len() == 0 {
+		return nil
+	}
+
+	datasetID, datasetOk := d.GetOk("dataset_id")
+	if !datasetOk {
+		return nil
+	}
+
+	client := meta.(*powerbiapi.Client)
+	return client.RefreshDatasetInGroupAndWait(d.Get("workspace_id").(string), datasetID.(string), timeout)
+}
+
 func readPBIXDatasources(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*powerbiapi.Client)
@@ -501,37 +1437,84 @@ func readPBIXDatasources(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	d.SetPartial("current_datasources")
+	d.Set("current_datasources", genericMap(apiDatasources.Value, flattenPBIXDatasource))
+
 	// Because datasource updates work in "find and replace" kind of semantic, it is
 	// impossible to track the values of individual datasources. However we can
-	// determine if there are no datasource that match our original replacement
+	// determine if there are no datasource that match our original replacement.
+	// Those are removed from state so that the plan shows them being reapplied
+	matchedDatasources := schema.NewSet(stateDatasources.F, nil)
 	for _, stateDatasource := range stateDatasources.List() {
 		stateDatasourceObj := stateDatasource.(map[string]interface{})
-		anyAPIMatchesState := false
 		for _, apiDatasource := range apiDatasources.Value {
-			apiMatchesState := (stateDatasourceObj["url"] == "" || stateDatasourceObj["url"] == *apiDatasource.ConnectionDetails.URL) &&
-				(stateDatasourceObj["server"] == "" || stateDatasourceObj["server"] == *apiDatasource.ConnectionDetails.Server) &&
-				(stateDatasourceObj["database"] == "" || stateDatasourceObj["database"] == *apiDatasource.ConnectionDetails.Database)
-			anyAPIMatchesState = anyAPIMatchesState || apiMatchesState
-		}
-
-		if !anyAPIMatchesState {
-			if stateDatasourceObj["url"] != "" {
-				stateDatasourceObj["url"] = "???"
-			}
-			if stateDatasourceObj["server"] != "" {
-				stateDatasourceObj["server"] = "???"
-			}
-			if stateDatasourceObj["database"] != "" {
-				stateDatasourceObj["database"] = "???"
+			if pbixDatasourceMatches(stateDatasourceObj, apiDatasource) {
+				matchedDatasources.Add(stateDatasource)
+				break
 			}
 		}
 	}
 
 	d.SetPartial("datasource")
-	d.Set("datasource", stateDatasources)
+	d.Set("datasource", matchedDatasources)
 	return nil
 }
 
+func pbixDatasourceMatches(stateDatasourceObj map[string]interface{}, apiDatasource powerbiapi.GetDatasourcesInGroupResponseItem) bool {
+	if stateDatasourceObj["type"] != "" && !strings.EqualFold(stateDatasourceObj["type"].(string), apiDatasource.DatasourceType) {
+		return false
+	}
+
+	for key, apiValue := range pbixDatasourceConnectionDetailFields(apiDatasource.ConnectionDetails) {
+		if stateDatasourceObj[key] != "" && stateDatasourceObj[key] != nilToEmptyString(apiValue) {
+			return false
+		}
+	}
+	return true
+}
+
+func pbixDatasourceConnectionDetailFields(connectionDetails powerbiapi.GetDatasourcesInGroupResponseItemConnectionDetails) map[string]*string {
+	return map[string]*string{
+		"database":      connectionDetails.Database,
+		"server":        connectionDetails.Server,
+		"url":           connectionDetails.URL,
+		"path":          connectionDetails.Path,
+		"kind":          connectionDetails.Kind,
+		"account":       connectionDetails.Account,
+		"domain":        connectionDetails.Domain,
+		"email_address": connectionDetails.EmailAddress,
+		"login_server":  connectionDetails.LoginServer,
+		"class_info":    connectionDetails.ClassInfo,
+	}
+}
+
+func expandPBIXDatasourceConnectionDetails(datasourceObj map[string]interface{}, prefix string) powerbiapi.UpdateDatasourcesInGroupRequestItemConnectionDetails {
+	return powerbiapi.UpdateDatasourcesInGroupRequestItemConnectionDetails{
+		Database:     emptyStringToNil(datasourceObj[prefix+"database"].(string)),
+		Server:       emptyStringToNil(datasourceObj[prefix+"server"].(string)),
+		URL:          emptyStringToNil(datasourceObj[prefix+"url"].(string)),
+		Path:         emptyStringToNil(datasourceObj[prefix+"path"].(string)),
+		Kind:         emptyStringToNil(datasourceObj[prefix+"kind"].(string)),
+		Account:      emptyStringToNil(datasourceObj[prefix+"account"].(string)),
+		Domain:       emptyStringToNil(datasourceObj[prefix+"domain"].(string)),
+		EmailAddress: emptyStringToNil(datasourceObj[prefix+"email_address"].(string)),
+		LoginServer:  emptyStringToNil(datasourceObj[prefix+"login_server"].(string)),
+		ClassInfo:    emptyStringToNil(datasourceObj[prefix+"class_info"].(string)),
+	}
+}
+
+func flattenPBIXDatasource(apiDatasource powerbiapi.GetDatasourcesInGroupResponseItem) map[string]interface{} {
+	datasourceObj := map[string]interface{}{
+		"type":          apiDatasource.DatasourceType,
+		"gateway_id":    apiDatasource.GatewayID,
+		"datasource_id": apiDatasource.DatasourceID,
+	}
+	for key, apiValue := range pbixDatasourceConnectionDetailFields(apiDatasource.ConnectionDetails) {
+		datasourceObj[key] = nilToEmptyString(apiValue)
+	}
+	return datasourceObj
+}
+
 func rebindPBIXDataset(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*powerbiapi.Client)
 
@@ -544,9 +1527,14 @@ func rebindPBIXDataset(d *schema.ResourceData, meta interface{}) error {
 		return nil
 	}
 
-	return client.RebindReportInGroup(groupID, reportID.(string), powerbiapi.RebindReportInGroupRequest{
+	// rebinding is always done in the report workspace, the dataset may be in rebind_dataset_workspace_id
+	err := client.RebindReportInGroup(groupID, reportID.(string), powerbiapi.RebindReportInGroupRequest{
 		DatasetID: rebindDatasetID.(string),
 	})
+	if isHTTP401Error(err) || isHTTP403Error(err) {
+		return fmt.Errorf("Unable to rebind the report to dataset '%s'. Build permission is required on the dataset: %s", rebindDatasetID.(string), err)
+	}
+	return err
 }
 
 func unbindPBIXDataset(d *schema.ResourceData, meta interface{}) error {
@@ -557,7 +1545,8 @@ func unbindPBIXDataset(d *schema.ResourceData, meta interface{}) error {
 	reportID, reportOk := d.GetOk("report_id")
 	_, rebindDatasetOk := d.GetOk("rebind_dataset_id")
 
-	// Only unbind if we either know we are rebinded, or rebinding has changed and we might be rebinded
+	// Only unbind if we either know we are rebinded, or rebinding has changed and we might be rebinded.
+	// The original dataset is always in the report workspace, even if the report was rebinded to another workspace
 	isRebinded := rebindDatasetOk || d.HasChange("rebind_dataset_id")
 
 	if !isRebinded || !datasetOk || !reportOk {
@@ -569,6 +1558,88 @@ func unbindPBIXDataset(d *schema.ResourceData, meta interface{}) error {
 	})
 }
 
+func customizeDiffPBIXRebindDataset(d *schema.ResourceDiff, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	// datasets created in the same apply are not known until then, in which case the rebind is validated by the API when applied.
+	// Unchanged rebinds are not validated again, to avoid looking up the dataset on every plan
+	if !d.NewValueKnown("rebind_dataset_id") || !d.NewValueKnown("rebind_dataset_workspace_id") || !d.NewValueKnown("workspace_id") {
+		return nil
+	}
+	if !d.HasChange("rebind_dataset_id") && !d.HasChange("rebind_dataset_workspace_id") {
+		return nil
+	}
+
+	rebindDatasetID := d.Get("rebind_dataset_id").(string)
+	if rebindDatasetID == "" {
+		return nil
+	}
+
+	groupID := d.Get("workspace_id").(string)
+	rebindGroupID := d.Get("rebind_dataset_workspace_id").(string)
+	if rebindGroupID == "" {
+		rebindGroupID = groupID
+	}
+
+	return validatePBIXRebindDataset(client, groupID, rebindGroupID, rebindDatasetID)
+}
+
+// validatePBIXRebindDataset checks the dataset a report will be rebinded to exists, and the caller has Build permission on it
+func validatePBIXRebindDataset(client *powerbiapi.Client, groupID string, rebindGroupID string, datasetID string) error {
+
+	_, err := client.GetDatasetInGroup(rebindGroupID, datasetID)
+	if isHTTP404Error(err) {
+		return fmt.Errorf("Dataset '%s' to rebind to does not exist in workspace '%s'", datasetID, rebindGroupID)
+	}
+	if err != nil {
+		return err
+	}
+
+	// the caller deploys into the report workspace, which requires a role that grants Build permission
+	if strings.EqualFold(groupID, rebindGroupID) {
+		return nil
+	}
+
+	groups, err := client.GetGroupsWithRoles([]string{"Admin", "Member", "Contributor"})
+	if err != nil {
+		return err
+	}
+	for _, group := range groups.Value {
+		if strings.EqualFold(group.ID, rebindGroupID) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Build permission is required on dataset '%s' to rebind to it. Assign the Admin, Member or Contributor role in workspace '%s'", datasetID, rebindGroupID)
+}
+
+func renamePBIX(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	name := d.Get("name").(string)
+
+	if reportID, reportOk := d.GetOk("report_id"); reportOk {
+		err := client.UpdateReportNameInGroup(groupID, reportID.(string), powerbiapi.UpdateReportNameInGroupRequest{
+			DisplayName: name,
+		})
+		if err != nil && !isHTTP404Error(err) {
+			return err
+		}
+	}
+
+	if datasetID, datasetOk := d.GetOk("dataset_id"); datasetOk {
+		err := client.UpdateDatasetNameInGroup(groupID, datasetID.(string), powerbiapi.UpdateDatasetNameInGroupRequest{
+			DisplayName: name,
+		})
+		if err != nil && !isHTTP404Error(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func takeOverPBIXReport(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*powerbiapi.Client)
 
@@ -584,7 +1655,8 @@ func takeOverPBIXReport(d *schema.ResourceData, meta interface{}) error {
 		err = client.TakeOverReportInGroup(groupID, reportID.(string))
 	}
 
-	if err != nil {
+	// nothing to take over if the content was deleted outside of terraform
+	if err != nil && !isHTTP404Error(err) {
 		return err
 	}
 
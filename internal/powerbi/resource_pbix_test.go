@@ -55,6 +55,9 @@ func TestAccPBIX_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("powerbi_pbix.test", "dataset_id"),
 					resource.TestCheckResourceAttrSet("powerbi_pbix.test", "report_id"),
 					resource.TestCheckResourceAttr("powerbi_pbix.test", "name", "Acceptance Test PBIX"),
+					resource.TestCheckResourceAttr("powerbi_pbix.test", "reports.#", "1"),
+					resource.TestCheckResourceAttr("powerbi_pbix.test", "datasets.#", "1"),
+					resource.TestCheckResourceAttrPair("powerbi_pbix.test", "reports.0.id", "powerbi_pbix.test", "report_id"),
 				),
 			},
 			// update with different pbix same path
@@ -101,6 +104,385 @@ func TestAccPBIX_basic(t *testing.T) {
 	})
 }
 
+func TestResourcePBIX_stateUpgradeV0(t *testing.T) {
+	expectedContentHash, err := calculateContentHash("./resource_pbix_test_sample1.pbix")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name                string
+		rawState            map[string]interface{}
+		expectedContentHash interface{}
+	}{
+		{
+			name:                "calculates the hash from source",
+			rawState:            map[string]interface{}{"source": "./resource_pbix_test_sample1.pbix"},
+			expectedContentHash: expectedContentHash,
+		},
+		{
+			name:                "uses source_hash when set",
+			rawState:            map[string]interface{}{"source": "./resource_pbix_test_sample1.pbix", "source_hash": "custom"},
+			expectedContentHash: "custom",
+		},
+		{
+			name:                "leaves the hash unset when source does not exist",
+			rawState:            map[string]interface{}{"source": "./does_not_exist.pbix"},
+			expectedContentHash: nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			upgradedState, err := upgradePBIXStateV0(c.rawState, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if upgradedState["source_content_hash"] != c.expectedContentHash {
+				t.Fatalf("Expected source_content_hash %v, found %v", c.expectedContentHash, upgradedState["source_content_hash"])
+			}
+		})
+	}
+}
+
+func TestAccPBIX_content_hash(t *testing.T) {
+	var updatedTime time.Time
+	pbixLocation := TempFileName("", ".pbix")
+	pbixLocationTfFriendly := strings.ReplaceAll(pbixLocation, "\\", "\\\\")
+	workspaceSuffix := acctest.RandString(6)
+
+	// no source_hash is configured, so changes are detected from the file content
+	config := fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_pbix" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test PBIX"
+		source = "%s"
+	}
+	`, workspaceSuffix, pbixLocationTfFriendly)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step creates the resource
+			{
+				PreConfig: func() {
+					Copy("./resource_pbix_test_sample1.pbix", pbixLocation)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					setUpdatedTime("powerbi_pbix.test", &updatedTime),
+					resource.TestCheckResourceAttrSet("powerbi_pbix.test", "source_content_hash"),
+				),
+			},
+			// update with different pbix same path
+			{
+				PreConfig: func() {
+					Copy("./resource_pbix_test_sample2.pbix", pbixLocation)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckUpdatedAfter("powerbi_pbix.test", &updatedTime), //update has occured since creation
+					resource.TestCheckResourceAttrSet("powerbi_pbix.test", "source_content_hash"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPBIX_republished_outside_terraform(t *testing.T) {
+	var importUpdatedTime string
+	var groupID string
+	pbixLocation := TempFileName("", ".pbix")
+	pbixLocationTfFriendly := strings.ReplaceAll(pbixLocation, "\\", "\\\\")
+	workspaceSuffix := acctest.RandString(6)
+
+	config := fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_pbix" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test PBIX"
+		source = "%s"
+	}
+	`, workspaceSuffix, pbixLocationTfFriendly)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step creates the resource
+			{
+				PreConfig: func() {
+					Copy("./resource_pbix_test_sample1.pbix", pbixLocation)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbix.test", "import_updated_time", &importUpdatedTime),
+					set("powerbi_workspace.test", "id", &groupID),
+				),
+			},
+			// second step republishes the PBIX outside of terraform, which should be reuploaded
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*powerbiapi.Client)
+					reader, err := os.Open("./resource_pbix_test_sample2.pbix")
+					if err != nil {
+						t.Fatal(err)
+					}
+					defer reader.Close()
+					im, err := client.PostImportInGroup(groupID, "Acceptance Test PBIX", "CreateOrOverwrite", false, reader)
+					if err != nil {
+						t.Fatal(err)
+					}
+					_, err = client.WaitForImportInGroupToSucceed(groupID, im.ID, 5*time.Minute)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckResourceAttrNotEquals("powerbi_pbix.test", "import_updated_time", &importUpdatedTime),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPBIX_deleted_outside_terraform(t *testing.T) {
+	var reportID string
+	var groupID string
+	pbixLocation := TempFileName("", ".pbix")
+	pbixLocationTfFriendly := strings.ReplaceAll(pbixLocation, "\\", "\\\\")
+	workspaceSuffix := acctest.RandString(6)
+
+	config := fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_pbix" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test PBIX"
+		source = "%s"
+	}
+	`, workspaceSuffix, pbixLocationTfFriendly)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step creates the resource
+			{
+				PreConfig: func() {
+					Copy("./resource_pbix_test_sample1.pbix", pbixLocation)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbix.test", "report_id", &reportID),
+					set("powerbi_workspace.test", "id", &groupID),
+				),
+			},
+			// second step deletes the report outside of terraform, which should be recreated
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*powerbiapi.Client)
+					client.DeleteReportInGroup(groupID, reportID)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIX"),
+					testCheckResourceAttrNotEquals("powerbi_pbix.test", "report_id", &reportID),
+					set("powerbi_pbix.test", "report_id", &reportID),
+				),
+			},
+			// third step deletes the report outside of terraform before destroying
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*powerbiapi.Client)
+					client.DeleteReportInGroup(groupID, reportID)
+				},
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckDatasetDoesNotExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIX"),
+					testCheckResourceRemoved("powerbi_pbix.test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPBIX_name_conflict(t *testing.T) {
+	var datasetID string
+	var importUpdatedTime string
+	name := "Acceptance Test PBIX"
+	pbixLocation := TempFileName("", ".pbix")
+	pbixLocationTfFriendly := strings.ReplaceAll(pbixLocation, "\\", "\\\\")
+	workspaceSuffix := acctest.RandString(6)
+
+	config := fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_pbix" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test PBIX"
+		source = "%s"
+	}
+	`, workspaceSuffix, pbixLocationTfFriendly)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerbi_pbix" "test" {
+					workspace_id = "validation-should-fail-before-using-this"
+					name = "validation-should-fail-before-using-this"
+					source = "validation-should-fail-before-using-this"
+					name_conflict = "Ignore"
+				}
+				`,
+				ExpectError: regexp.MustCompile("config is invalid:.*name_conflict.*"),
+			},
+			// first step creates the resource
+			{
+				PreConfig: func() {
+					Copy("./resource_pbix_test_sample1.pbix", pbixLocation)
+				},
+				Config: config,
+			},
+			// second step attempts to upload another PBIX with the same name
+			{
+				Config: config + fmt.Sprintf(`
+				resource "powerbi_pbix" "conflict" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "%s"
+					name_conflict = "Abort"
+				}
+				`, pbixLocationTfFriendly),
+				ExpectError: regexp.MustCompile("DuplicatePackageNotFoundError|PowerBIEntityAlreadyExists|already exists"),
+			},
+			// third step uploads another PBIX with the same name under a unique name
+			{
+				Config: config + fmt.Sprintf(`
+				resource "powerbi_pbix" "unique" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "%s"
+					name_conflict = "GenerateUniqueName"
+				}
+				`, pbixLocationTfFriendly),
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbix.test", "dataset_id", &datasetID),
+					set("powerbi_pbix.test", "import_updated_time", &importUpdatedTime),
+					resource.TestCheckResourceAttr("powerbi_pbix.test", "content_name", "Acceptance Test PBIX"),
+					testCheckResourceAttrNotEquals("powerbi_pbix.unique", "content_name", &name),
+					testCheckResourceAttrNotEquals("powerbi_pbix.unique", "dataset_id", &datasetID),
+				),
+			},
+			// fourth step reuploads the uniquely named PBIX, which should not overwrite the other PBIX
+			{
+				Config: config + fmt.Sprintf(`
+				resource "powerbi_pbix" "unique" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "%s"
+					source_hash = "reupload"
+					name_conflict = "GenerateUniqueName"
+				}
+				`, pbixLocationTfFriendly),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("powerbi_pbix.test", "dataset_id", &datasetID),
+					resource.TestCheckResourceAttrPtr("powerbi_pbix.test", "import_updated_time", &importUpdatedTime),
+					testCheckResourceAttrNotEquals("powerbi_pbix.unique", "content_name", &name),
+					testCheckResourceAttrNotEquals("powerbi_pbix.unique", "dataset_id", &datasetID),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPBIX_rename(t *testing.T) {
+	var reportID string
+	var datasetID string
+	pbixLocation := TempFileName("", ".pbix")
+	pbixLocationTfFriendly := strings.ReplaceAll(pbixLocation, "\\", "\\\\")
+	workspaceSuffix := acctest.RandString(6)
+
+	config := func(name string) string {
+		return fmt.Sprintf(`
+		resource "powerbi_workspace" "test" {
+			name = "Acceptance Test Workspace %s"
+		}
+
+		resource "powerbi_pbix" "test" {
+			workspace_id = "${powerbi_workspace.test.id}"
+			name = "%s"
+			source = "%s"
+		}
+		`, workspaceSuffix, name, pbixLocationTfFriendly)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step creates the resource
+			{
+				PreConfig: func() {
+					Copy("./resource_pbix_test_sample1.pbix", pbixLocation)
+				},
+				Config: config("Acceptance Test PBIX"),
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbix.test", "report_id", &reportID),
+					set("powerbi_pbix.test", "dataset_id", &datasetID),
+				),
+			},
+			// second step renames the report and dataset in place
+			{
+				Config: config("Acceptance Test PBIX Renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIX Renamed"),
+					testCheckDatasetExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIX Renamed"),
+					resource.TestCheckResourceAttrPtr("powerbi_pbix.test", "report_id", &reportID),
+					resource.TestCheckResourceAttrPtr("powerbi_pbix.test", "dataset_id", &datasetID),
+				),
+			},
+			// third step reuploads the renamed PBIX, which should overwrite the renamed content
+			{
+				PreConfig: func() {
+					Copy("./resource_pbix_test_sample2.pbix", pbixLocation)
+				},
+				Config: config("Acceptance Test PBIX Renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIX Renamed"),
+					testCheckReportDoesNotExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIX"),
+					resource.TestCheckResourceAttrPtr("powerbi_pbix.test", "report_id", &reportID),
+				),
+			},
+		},
+	})
+}
+
 func TestAccPBIX_external_dataset_report(t *testing.T) {
 	datasetPbixLocation := TempFileName("", ".pbix")
 	datasetPbixLocationTfFriendly := strings.ReplaceAll(datasetPbixLocation, "\\", "\\\\")
@@ -251,6 +633,119 @@ func TestAccPBIX_external_dataset_report(t *testing.T) {
 	})
 }
 
+func TestAccPBIX_rewrite(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+	var datasetID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// the report is rewritten to point to the dataset before upload
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "dataset_only" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test dataset PBIX"
+					source = "./resource_pbix_dataset_only.pbix"
+					source_hash = "${filemd5("./resource_pbix_dataset_only.pbix")}"
+					skip_report = true
+				}
+
+				resource "powerbi_pbix" "report_only" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test report PBIX"
+					source = "./resource_pbix_report_only.pbix"
+					source_hash = "${filemd5("./resource_pbix_report_only.pbix")}"
+					rewrite {
+						dataset_id = "${powerbi_pbix.dataset_only.dataset_id}"
+						strip_security_bindings = true
+					}
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbix.dataset_only", "dataset_id", &datasetID),
+					testCheckDatasetDoesNotExistsInWorkspace("powerbi_workspace.test", "Acceptance Test report PBIX"),
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test report PBIX"),
+					testCheckReportDataset("powerbi_pbix.report_only", &datasetID),
+				),
+			},
+			// second step also rewrites the layout and theme of the report
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "dataset_only" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test dataset PBIX"
+					source = "./resource_pbix_dataset_only.pbix"
+					source_hash = "${filemd5("./resource_pbix_dataset_only.pbix")}"
+					skip_report = true
+				}
+
+				resource "powerbi_pbix" "report_only" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test report PBIX"
+					source = "./resource_pbix_report_only.pbix"
+					source_hash = "${filemd5("./resource_pbix_report_only.pbix")}"
+					rewrite {
+						dataset_id = "${powerbi_pbix.dataset_only.dataset_id}"
+						active_page = "Page 1"
+						page {
+							name = "Page 1"
+							hidden = false
+						}
+						theme {
+							name = "CY20SU09.json"
+							content = jsonencode({ name = "Acceptance Test Theme" })
+						}
+					}
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test report PBIX"),
+					testCheckReportDataset("powerbi_pbix.report_only", &datasetID),
+				),
+			},
+			// referencing a page that does not exist fails before upload
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "dataset_only" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test dataset PBIX"
+					source = "./resource_pbix_dataset_only.pbix"
+					source_hash = "${filemd5("./resource_pbix_dataset_only.pbix")}"
+					skip_report = true
+				}
+
+				resource "powerbi_pbix" "report_only" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test report PBIX"
+					source = "./resource_pbix_report_only.pbix"
+					source_hash = "${filemd5("./resource_pbix_report_only.pbix")}"
+					rewrite {
+						dataset_id = "${powerbi_pbix.dataset_only.dataset_id}"
+						active_page = "Page Does Not Exist"
+					}
+				}
+				`, workspaceSuffix),
+				ExpectError: regexp.MustCompile("Page 'Page Does Not Exist' does not exist in the report"),
+			},
+		},
+	})
+}
+
 func TestAccPBIX_rebind_dataset(t *testing.T) {
 	datasetPbixLocation := TempFileName("dataset_", ".pbix")
 	datasetPbixLocationTfFriendly := strings.ReplaceAll(datasetPbixLocation, "\\", "\\\\")
@@ -422,6 +917,87 @@ func TestAccPBIX_rebind_dataset(t *testing.T) {
 	})
 }
 
+func TestAccPBIX_rebind_dataset_other_workspace(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+	var report_datasetID string
+	var dataset_datasetID string
+
+	baseConfig := fmt.Sprintf(`
+	resource "powerbi_workspace" "datasets" {
+		name = "Acceptance Test Workspace datasets %s"
+	}
+
+	resource "powerbi_workspace" "reports" {
+		name = "Acceptance Test Workspace reports %s"
+	}
+
+	resource "powerbi_pbix" "dataset_only" {
+		workspace_id = "${powerbi_workspace.datasets.id}"
+		name = "Acceptance Test dataset PBIX"
+		source = "./resource_pbix_test_sample1.pbix"
+		source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+		skip_report = true
+	}
+	`, workspaceSuffix, workspaceSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step rebinds the report to a dataset in another workspace
+			{
+				Config: baseConfig + `
+				resource "powerbi_pbix" "report_only" {
+					workspace_id = "${powerbi_workspace.reports.id}"
+					name = "Acceptance Test report PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+					rebind_dataset_id = powerbi_pbix.dataset_only.dataset_id
+					rebind_dataset_workspace_id = powerbi_workspace.datasets.id
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbix.dataset_only", "dataset_id", &dataset_datasetID),
+					testCheckReportDataset("powerbi_pbix.report_only", &dataset_datasetID),
+				),
+			},
+
+			// second step removes the binding, which unbinds back to the dataset in the report workspace
+			{
+				Config: baseConfig + `
+				resource "powerbi_pbix" "report_only" {
+					workspace_id = "${powerbi_workspace.reports.id}"
+					name = "Acceptance Test report PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbix.report_only", "dataset_id", &report_datasetID),
+					testCheckReportDataset("powerbi_pbix.report_only", &report_datasetID),
+				),
+			},
+
+			// third step validates the dataset exists during plan
+			{
+				Config: baseConfig + `
+				resource "powerbi_pbix" "report_only" {
+					workspace_id = "${powerbi_workspace.reports.id}"
+					name = "Acceptance Test report PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+					rebind_dataset_id = "00000000-0000-0000-0000-000000000000"
+					rebind_dataset_workspace_id = powerbi_workspace.datasets.id
+				}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("to rebind to does not exist in workspace"),
+			},
+		},
+	})
+}
+
 func TestAccPBIX_rebind_dataset_with_update(t *testing.T) {
 	datasetPbixLocation := TempFileName("dataset_", ".pbix")
 	datasetPbixLocationTfFriendly := strings.ReplaceAll(datasetPbixLocation, "\\", "\\\\")
@@ -708,8 +1284,30 @@ func TestAccPBIX_parameters(t *testing.T) {
 					testCheckUpdatedAt("powerbi_pbix.test", &updatedTime), //import should not be updated
 					testCheckParameter("powerbi_pbix.test", "ParamOne", "NewParamValueOne"),
 					testCheckParameter("powerbi_pbix.test", "ParamTwo", "DriftedValue"),
+					resource.TestCheckResourceAttr("powerbi_pbix.test", "all_parameters.#", "2"),
+					resource.TestCheckResourceAttrSet("powerbi_pbix.test", "all_parameters.0.type"),
 				),
 			},
+			// parameters that do not exist in the dataset should be rejected
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+					parameter {
+						name = "ParamDoesNotExist"
+						value = "NewParamValueOne"
+					}
+				}
+				`, workspaceSuffix),
+				ExpectError: regexp.MustCompile("Parameter 'ParamDoesNotExist' does not exist in the PBIX dataset"),
+			},
 			// uploading new file should also update with parameters
 			{
 				Config: fmt.Sprintf(`
@@ -738,6 +1336,66 @@ func TestAccPBIX_parameters(t *testing.T) {
 	})
 }
 
+func TestAccPBIX_parameters_refresh(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step creates the pbix with parameters and refreshes the dataset
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+					refresh_on_parameter_change = true
+					parameter {
+						name = "ParamOne"
+						value = "NewParamValueOne"
+					}
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckParameter("powerbi_pbix.test", "ParamOne", "NewParamValueOne"),
+					testCheckDatasetRefreshCount("powerbi_pbix.test", 1),
+				),
+			},
+			// second step updates the parameter which should refresh again
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+					refresh_on_parameter_change = true
+					parameter {
+						name = "ParamOne"
+						value = "UpdatedParamValueOne"
+					}
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckParameter("powerbi_pbix.test", "ParamOne", "UpdatedParamValueOne"),
+					testCheckDatasetRefreshCount("powerbi_pbix.test", 2),
+				),
+			},
+		},
+	})
+}
+
 func TestAccPBIX_datasources(t *testing.T) {
 	var updatedTime time.Time
 	var datasetID string
@@ -773,6 +1431,9 @@ func TestAccPBIX_datasources(t *testing.T) {
 					set("powerbi_pbix.test", "workspace_id", &groupID),
 					setUpdatedTime("powerbi_pbix.test", &updatedTime),
 					testCheckURLDatasource("powerbi_pbix.test", "https://services.odata.org/V3/(S(kbiqo1qkby04vnobw0li0fcp))/OData/OData.svc"),
+					resource.TestCheckResourceAttr("powerbi_pbix.test", "current_datasources.#", "1"),
+					resource.TestCheckResourceAttr("powerbi_pbix.test", "current_datasources.0.type", "OData"),
+					resource.TestCheckResourceAttr("powerbi_pbix.test", "current_datasources.0.url", "https://services.odata.org/V3/(S(kbiqo1qkby04vnobw0li0fcp))/OData/OData.svc"),
 				),
 			},
 			// apply same config with drift
@@ -816,8 +1477,30 @@ func TestAccPBIX_datasources(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testCheckUpdatedAfter("powerbi_pbix.test", &updatedTime), //import should be updated
 					testCheckURLDatasource("powerbi_pbix.test", "https://services.odata.org/V3/(S(kbiqo1qkby04vnobw0li0fcp))/OData/OData.svc"),
+					resource.TestCheckResourceAttr("powerbi_pbix.test", "current_datasources.0.url", "https://services.odata.org/V3/(S(kbiqo1qkby04vnobw0li0fcp))/OData/OData.svc"),
 				),
 			},
+			// selecting a datasource by id requires the gateway id
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+					datasource {
+						url = "https://services.odata.org/V3/OData/OData.svc"
+						datasource_id = "00000000-0000-0000-0000-000000000000"
+					}
+				}
+				`, workspaceSuffix),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("must also set gateway_id"),
+			},
 		},
 	})
 }
@@ -1111,6 +1794,39 @@ func testCheckUpdatedAt(pbixResourceName string, updatedAt *time.Time) resource.
 	}
 }
 
+func testCheckDatasetRefreshCount(pbixResourceName string, expectedCompletedRefreshes int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		datasetID, err := getResourceProperty(s, pbixResourceName, "dataset_id")
+		if err != nil {
+			return err
+		}
+
+		groupID, err := getResourceProperty(s, pbixResourceName, "workspace_id")
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*powerbiapi.Client)
+		history, err := client.GetRefreshHistoryInGroup(groupID, datasetID, 100)
+		if err != nil {
+			return err
+		}
+
+		completedRefreshes := 0
+		for _, refresh := range history.Value {
+			if refresh.Status == "Completed" {
+				completedRefreshes++
+			}
+		}
+		if completedRefreshes != expectedCompletedRefreshes {
+			return fmt.Errorf("Expecting %v completed refreshes. Found %v", expectedCompletedRefreshes, completedRefreshes)
+		}
+
+		return nil
+	}
+}
+
 func testCheckParameter(pbixResourceName string, expectedParameterName string, expectedParameterValue string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
@@ -1165,7 +1881,7 @@ func testCheckURLDatasource(pbixResourceName string, expectedValue string) resou
 
 		var urlValues []string
 		for _, datasource := range datasources.Value {
-			if *datasource.ConnectionDetails.URL == expectedValue {
+			if nilToEmptyString(datasource.ConnectionDetails.URL) == expectedValue {
 				return nil
 			} else if datasource.ConnectionDetails.URL != nil {
 				urlValues = append(urlValues, *datasource.ConnectionDetails.URL)
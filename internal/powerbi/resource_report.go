@@ -0,0 +1,280 @@
+package powerbi
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/pbixrewriter"
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ResourceReport represents a thin Power BI report deployed from a report-only PBIX and bound to an existing dataset
+func ResourceReport() *schema.Resource {
+	return &schema.Resource{
+		Create: createReport,
+		Read:   readReport,
+		Update: updateReport,
+		Delete: deleteReport,
+
+		CustomizeDiff: customdiff.All(
+			customizeDiffSourceContentHash,
+			customizeDiffReportSource,
+		),
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the report will be added.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the report. Changing this value renames the report in place.",
+				Required:    true,
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Description: "An absolute path to a report-only PBIX file on the local system. The PBIX must use a live connection and must not contain a data model, which is checked during plan.",
+				Required:    true,
+			},
+			"source_hash": {
+				Type:        schema.TypeString,
+				Description: "Used to trigger updates. If set, this value is used instead of the content hash calculated from `source`.",
+				Optional:    true,
+			},
+			"source_content_hash": {
+				Type:        schema.TypeString,
+				Description: "The MD5 hash of the PBIX content, calculated during plan. A change in this value will reupload the PBIX. If `source_hash` is set it is used as the value instead.",
+				Computed:    true,
+			},
+			"dataset_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the dataset the report is bound to. The live connection of the PBIX is rewritten to this dataset before uploading. Changing this value rebinds the report in place.",
+				Required:    true,
+			},
+			"report_id": {
+				Type:        schema.TypeString,
+				Description: "The ID for the report.",
+				Computed:    true,
+			},
+			"web_url": {
+				Type:        schema.TypeString,
+				Description: "The web URL of the report.",
+				Computed:    true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func createReport(d *schema.ResourceData, meta interface{}) error {
+
+	d.Partial(true)
+
+	err := createReportImport(d, meta, "Abort")
+	if err != nil {
+		return err
+	}
+
+	err = readReportImport(d, meta, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+
+	d.Partial(false)
+
+	return readReport(d, meta)
+}
+
+func readReport(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	err := readReportImport(d, meta, d.Timeout(schema.TimeoutRead))
+	if isHTTP404Error(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	reportID, ok := d.GetOk("report_id")
+	if !ok {
+		return nil
+	}
+
+	report, err := client.GetReportInGroup(d.Get("workspace_id").(string), reportID.(string))
+	if isHTTP404Error(err) {
+		// a report deleted through the portal is recreated, as there is nothing left for a reupload to overwrite
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("web_url", report.WebURL)
+	d.Set("dataset_id", report.DatasetID)
+
+	return nil
+}
+
+func updateReport(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	reportID := d.Get("report_id").(string)
+
+	if d.HasChange("name") {
+		err := client.UpdateReportNameInGroup(groupID, reportID, powerbiapi.UpdateReportNameInGroupRequest{
+			DisplayName: d.Get("name").(string),
+		})
+		if err != nil && !isHTTP404Error(err) {
+			return err
+		}
+	}
+
+	// a reupload is already pointed at the current dataset, so a rebind is only needed otherwise
+	if d.HasChange("source") || d.HasChange("source_hash") || d.HasChange("source_content_hash") {
+
+		d.Partial(true)
+
+		err := createReportImport(d, meta, "Overwrite")
+		if err != nil {
+			return err
+		}
+
+		err = readReportImport(d, meta, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+
+		d.Partial(false)
+
+	} else if d.HasChange("dataset_id") {
+		err := client.RebindReportInGroup(groupID, reportID, powerbiapi.RebindReportInGroupRequest{
+			DatasetID: d.Get("dataset_id").(string),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return readReport(d, meta)
+}
+
+func deleteReport(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	reportID, reportIDOk := d.GetOk("report_id")
+	if !reportIDOk {
+		return nil
+	}
+
+	// the report may have already been deleted outside of terraform
+	err := client.DeleteReportInGroup(d.Get("workspace_id").(string), reportID.(string))
+	if err != nil && !isHTTP404Error(err) {
+		return err
+	}
+	return nil
+}
+
+func createReportImport(d *schema.ResourceData, meta interface{}, nameConflict string) error {
+	client := meta.(*powerbiapi.Client)
+
+	source := d.Get("source").(string)
+
+	// the source is validated during plan, unless it was generated as part of the apply
+	err := validateReportOnlyPBIX(source)
+	if err != nil {
+		return err
+	}
+
+	reader, err := openRewrittenPBIX(source, []pbixrewriter.PipelineFunc{
+		pbixrewriter.SetDatasetIDPipelineFunc(d.Get("dataset_id").(string)),
+	})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	resp, err := client.PostImportInGroup(
+		d.Get("workspace_id").(string),
+		d.Get("name").(string),
+		nameConflict,
+		false,
+		reader,
+	)
+	if err != nil {
+		return err
+	}
+
+	contentHash := d.Get("source_hash").(string)
+	if contentHash == "" {
+		contentHash, err = calculateContentHash(source)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId(resp.ID)
+	d.SetPartial("workspace_id")
+	d.SetPartial("name")
+	d.SetPartial("source")
+	d.SetPartial("source_hash")
+	d.SetPartial("source_content_hash")
+	d.SetPartial("dataset_id")
+	d.Set("source_content_hash", contentHash)
+
+	return nil
+}
+
+func customizeDiffReportSource(d *schema.ResourceDiff, meta interface{}) error {
+
+	// the source may only be known or exist after other resources are applied, in which case it is validated when applied
+	if !d.NewValueKnown("source") || !d.NewValueKnown("source_content_hash") || !d.HasChange("source_content_hash") {
+		return nil
+	}
+
+	source := d.Get("source").(string)
+	if _, err := os.Stat(source); os.IsNotExist(err) {
+		return nil
+	}
+
+	return validateReportOnlyPBIX(source)
+}
+
+// validateReportOnlyPBIX checks the PBIX has no data model, as uploading it would create a dataset alongside the report
+func validateReportOnlyPBIX(source string) error {
+
+	info, err := pbixrewriter.InspectPbixFile(source)
+	if err != nil {
+		return err
+	}
+	if info.HasDataModel {
+		return fmt.Errorf("PBIX '%s' contains a data model. Only report-only PBIX files using a live connection can be deployed with powerbi_report, use powerbi_pbix instead", source)
+	}
+	return nil
+}
+
+func readReportImport(d *schema.ResourceData, meta interface{}, timeoutForSuccessfulImport time.Duration) error {
+	client := meta.(*powerbiapi.Client)
+
+	im, err := client.WaitForImportInGroupToSucceed(d.Get("workspace_id").(string), d.Id(), timeoutForSuccessfulImport)
+	if err != nil {
+		return err
+	}
+
+	if len(im.Reports) >= 1 {
+		d.SetPartial("report_id")
+		d.Set("report_id", im.Reports[0].ID)
+	}
+
+	return nil
+}
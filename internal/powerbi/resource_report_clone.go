@@ -0,0 +1,142 @@
+package powerbi
+
+import (
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ResourceReportClone represents a Power BI report cloned from another report, possibly in another workspace
+func ResourceReportClone() *schema.Resource {
+	return &schema.Resource{
+		Create: createReportClone,
+		Read:   readReportClone,
+		Update: updateReportClone,
+		Delete: deleteReportClone,
+
+		Schema: map[string]*schema.Schema{
+			"source_workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID of the report to clone.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"source_report_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the report to clone.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"target_workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the cloned report will be added.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"target_dataset_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the dataset the cloned report is bound to. If not set, the clone is bound to the dataset of the source report. Changing this value rebinds the cloned report in place.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the cloned report. Changing this value renames the cloned report in place.",
+				Required:    true,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values that, when changed, will clone the report again. Clones do not follow changes to the source report, so this can be used to clone again when the source report is updated.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"report_id": {
+				Type:        schema.TypeString,
+				Description: "The ID for the cloned report.",
+				Computed:    true,
+			},
+			"web_url": {
+				Type:        schema.TypeString,
+				Description: "The web URL of the cloned report.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func createReportClone(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	resp, err := client.CloneReportInGroup(d.Get("source_workspace_id").(string), d.Get("source_report_id").(string), powerbiapi.CloneReportInGroupRequest{
+		Name:              d.Get("name").(string),
+		TargetModelID:     d.Get("target_dataset_id").(string),
+		TargetWorkspaceID: d.Get("target_workspace_id").(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(resp.ID)
+
+	return readReportClone(d, meta)
+}
+
+func readReportClone(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	// a clone deleted outside of terraform is cloned again
+	report, err := client.GetReportInGroup(d.Get("target_workspace_id").(string), d.Id())
+	if isHTTP404Error(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", report.Name)
+	d.Set("target_dataset_id", report.DatasetID)
+	d.Set("report_id", report.ID)
+	d.Set("web_url", report.WebURL)
+
+	return nil
+}
+
+func updateReportClone(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("target_workspace_id").(string)
+
+	if d.HasChange("name") {
+		err := client.UpdateReportNameInGroup(groupID, d.Id(), powerbiapi.UpdateReportNameInGroupRequest{
+			DisplayName: d.Get("name").(string),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("target_dataset_id") {
+		err := client.RebindReportInGroup(groupID, d.Id(), powerbiapi.RebindReportInGroupRequest{
+			DatasetID: d.Get("target_dataset_id").(string),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return readReportClone(d, meta)
+}
+
+func deleteReportClone(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	// the clone may have already been deleted outside of terraform
+	err := client.DeleteReportInGroup(d.Get("target_workspace_id").(string), d.Id())
+	if err != nil && !isHTTP404Error(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package powerbi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccReportClone_basic(t *testing.T) {
+	var datasetID string
+	var cloneBReportID string
+	var cloneCReportID string
+	workspaceSuffix := acctest.RandString(6)
+
+	baseConfig := fmt.Sprintf(`
+	resource "powerbi_workspace" "master" {
+		name = "Acceptance Test Workspace master %s"
+	}
+
+	resource "powerbi_workspace" "b" {
+		name = "Acceptance Test Workspace b %s"
+	}
+
+	resource "powerbi_workspace" "c" {
+		name = "Acceptance Test Workspace c %s"
+	}
+
+	resource "powerbi_pbix" "master" {
+		workspace_id = "${powerbi_workspace.master.id}"
+		name = "Acceptance Test master"
+		source = "./resource_pbix_test_sample1.pbix"
+		source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+	}
+	`, workspaceSuffix, workspaceSuffix, workspaceSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step fans the master report out to two workspaces
+			{
+				Config: baseConfig + `
+				resource "powerbi_report_clone" "b" {
+					source_workspace_id = "${powerbi_workspace.master.id}"
+					source_report_id = "${powerbi_pbix.master.report_id}"
+					target_workspace_id = "${powerbi_workspace.b.id}"
+					name = "Acceptance Test clone"
+				}
+
+				resource "powerbi_report_clone" "c" {
+					source_workspace_id = "${powerbi_workspace.master.id}"
+					source_report_id = "${powerbi_pbix.master.report_id}"
+					target_workspace_id = "${powerbi_workspace.c.id}"
+					target_dataset_id = "${powerbi_pbix.master.dataset_id}"
+					name = "Acceptance Test clone"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbix.master", "dataset_id", &datasetID),
+					set("powerbi_report_clone.b", "report_id", &cloneBReportID),
+					set("powerbi_report_clone.c", "report_id", &cloneCReportID),
+					testCheckReportExistsInWorkspace("powerbi_workspace.b", "Acceptance Test clone"),
+					testCheckReportExistsInWorkspace("powerbi_workspace.c", "Acceptance Test clone"),
+					resource.TestCheckResourceAttrPtr("powerbi_report_clone.b", "target_dataset_id", &datasetID),
+					resource.TestCheckResourceAttrPtr("powerbi_report_clone.c", "target_dataset_id", &datasetID),
+				),
+			},
+			// second step renames one clone in place without affecting the other
+			{
+				Config: baseConfig + `
+				resource "powerbi_report_clone" "b" {
+					source_workspace_id = "${powerbi_workspace.master.id}"
+					source_report_id = "${powerbi_pbix.master.report_id}"
+					target_workspace_id = "${powerbi_workspace.b.id}"
+					name = "Acceptance Test clone renamed"
+				}
+
+				resource "powerbi_report_clone" "c" {
+					source_workspace_id = "${powerbi_workspace.master.id}"
+					source_report_id = "${powerbi_pbix.master.report_id}"
+					target_workspace_id = "${powerbi_workspace.c.id}"
+					target_dataset_id = "${powerbi_pbix.master.dataset_id}"
+					name = "Acceptance Test clone"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("powerbi_report_clone.b", "report_id", &cloneBReportID),
+					resource.TestCheckResourceAttrPtr("powerbi_report_clone.c", "report_id", &cloneCReportID),
+					testCheckReportExistsInWorkspace("powerbi_workspace.b", "Acceptance Test clone renamed"),
+					testCheckReportDoesNotExistsInWorkspace("powerbi_workspace.b", "Acceptance Test clone"),
+					testCheckReportExistsInWorkspace("powerbi_workspace.c", "Acceptance Test clone"),
+				),
+			},
+			// third step removes one clone, leaving the other in place
+			{
+				Config: baseConfig + `
+				resource "powerbi_report_clone" "c" {
+					source_workspace_id = "${powerbi_workspace.master.id}"
+					source_report_id = "${powerbi_pbix.master.report_id}"
+					target_workspace_id = "${powerbi_workspace.c.id}"
+					target_dataset_id = "${powerbi_pbix.master.dataset_id}"
+					name = "Acceptance Test clone"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckReportDoesNotExistsInWorkspace("powerbi_workspace.b", "Acceptance Test clone renamed"),
+					testCheckReportExistsInWorkspace("powerbi_workspace.c", "Acceptance Test clone"),
+					testCheckResourceRemoved("powerbi_report_clone.b"),
+				),
+			},
+		},
+	})
+}
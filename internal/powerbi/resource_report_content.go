@@ -0,0 +1,168 @@
+package powerbi
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ResourceReportContent represents the content of a long lived Power BI report, pushed from another report
+func ResourceReportContent() *schema.Resource {
+	return &schema.Resource{
+		Create: createReportContent,
+		Read:   readReportContent,
+		Update: updateReportContent,
+		Delete: deleteReportContent,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID of the report to update.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"report_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the report to update. The report keeps its ID and URL when its content is updated.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"source_workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID of the report to copy the content from.",
+				Required:    true,
+			},
+			"source_report_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the report to copy the content from, such as a staging report.",
+				Required:    true,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values that, when changed, will update the report content again. The content is otherwise only copied when `source_workspace_id` or `source_report_id` change, so this can be used to update again when the source report is updated.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the report.",
+				Computed:    true,
+			},
+			"dataset_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the dataset the report is bound to.",
+				Computed:    true,
+			},
+			"web_url": {
+				Type:        schema.TypeString,
+				Description: "The web URL of the report.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func createReportContent(d *schema.ResourceData, meta interface{}) error {
+
+	err := updateReportContentFromSource(d, meta)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("report_id").(string))
+
+	return readReportContent(d, meta)
+}
+
+func readReportContent(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	report, err := client.GetReportInGroup(d.Get("workspace_id").(string), d.Id())
+	if isHTTP404Error(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", report.Name)
+	d.Set("dataset_id", report.DatasetID)
+	d.Set("web_url", report.WebURL)
+
+	return nil
+}
+
+func updateReportContent(d *schema.ResourceData, meta interface{}) error {
+
+	if d.HasChange("source_workspace_id") || d.HasChange("source_report_id") || d.HasChange("triggers") {
+		err := updateReportContentFromSource(d, meta)
+		if err != nil {
+			return err
+		}
+	}
+
+	return readReportContent(d, meta)
+}
+
+func deleteReportContent(d *schema.ResourceData, meta interface{}) error {
+	// the report is not owned by this resource, so it is left with its current content
+	return nil
+}
+
+// updateReportContentFromSource copies the content of the source report into the report.
+// The previous content is kept in a temporary clone of the report, and is restored if the update fails
+func updateReportContentFromSource(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	reportID := d.Get("report_id").(string)
+
+	report, err := client.GetReportInGroup(groupID, reportID)
+	if err != nil {
+		return err
+	}
+
+	backup, err := client.CloneReportInGroup(groupID, reportID, powerbiapi.CloneReportInGroupRequest{
+		Name: fmt.Sprintf("%s (backup)", report.Name),
+	})
+	if err != nil {
+		return err
+	}
+
+	updateErr := pushReportContent(client, groupID, reportID, d.Get("source_workspace_id").(string), d.Get("source_report_id").(string))
+	if updateErr != nil {
+		rollbackErr := pushReportContent(client, groupID, reportID, groupID, backup.ID)
+		if rollbackErr != nil {
+			return fmt.Errorf("Unable to update the content of report '%s': %s. Restoring the previous content also failed, it is kept in report '%s': %s", reportID, updateErr, backup.ID, rollbackErr)
+		}
+	}
+
+	// the backup is only kept if the previous content could not be restored. Failing to delete it does not
+	// undo the update, so the report content is still tracked
+	err = client.DeleteReportInGroup(groupID, backup.ID)
+	if err != nil && !isHTTP404Error(err) {
+		log.Printf("[WARN] Unable to delete report '%s' backing up the previous content of report '%s': %s", backup.ID, reportID, err)
+	}
+
+	if updateErr != nil {
+		return fmt.Errorf("Unable to update the content of report '%s', the previous content was restored: %s", reportID, updateErr)
+	}
+	return nil
+}
+
+func pushReportContent(client *powerbiapi.Client, groupID string, reportID string, sourceGroupID string, sourceReportID string) error {
+
+	_, err := client.UpdateReportContentInGroup(groupID, reportID, powerbiapi.UpdateReportContentInGroupRequest{
+		SourceReport: powerbiapi.UpdateReportContentInGroupRequestSourceReport{
+			SourceReportID:    sourceReportID,
+			SourceWorkspaceID: sourceGroupID,
+		},
+		SourceType: "ExistingReport",
+	})
+	return err
+}
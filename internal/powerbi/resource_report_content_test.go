@@ -0,0 +1,81 @@
+package powerbi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccReportContent_basic(t *testing.T) {
+	var productionReportID string
+	workspaceSuffix := acctest.RandString(6)
+
+	config := func(stagingResource string) string {
+		return fmt.Sprintf(`
+		resource "powerbi_workspace" "test" {
+			name = "Acceptance Test Workspace %s"
+		}
+
+		resource "powerbi_pbix" "production" {
+			workspace_id = "${powerbi_workspace.test.id}"
+			name = "Acceptance Test production"
+			source = "./resource_pbix_test_sample1.pbix"
+			source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+		}
+
+		resource "powerbi_pbix" "staging_1" {
+			workspace_id = "${powerbi_workspace.test.id}"
+			name = "Acceptance Test staging 1"
+			source = "./resource_pbix_test_sample2.pbix"
+			source_hash = "${filemd5("./resource_pbix_test_sample2.pbix")}"
+		}
+
+		resource "powerbi_pbix" "staging_2" {
+			workspace_id = "${powerbi_workspace.test.id}"
+			name = "Acceptance Test staging 2"
+			source = "./resource_pbix_test_sample1.pbix"
+			source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+		}
+
+		resource "powerbi_report_content" "test" {
+			workspace_id = "${powerbi_workspace.test.id}"
+			report_id = "${powerbi_pbix.production.report_id}"
+			source_workspace_id = "${powerbi_workspace.test.id}"
+			source_report_id = "${powerbi_pbix.%s.report_id}"
+		}
+		`, workspaceSuffix, stagingResource)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step pushes the staging content into the production report
+			{
+				Config: config("staging_1"),
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbix.production", "report_id", &productionReportID),
+					resource.TestCheckResourceAttrPtr("powerbi_report_content.test", "id", &productionReportID),
+					resource.TestCheckResourceAttr("powerbi_report_content.test", "name", "Acceptance Test production"),
+					resource.TestCheckResourceAttrPair("powerbi_report_content.test", "source_report_id", "powerbi_pbix.staging_1", "report_id"),
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test production"),
+					testCheckReportDoesNotExistsInWorkspace("powerbi_workspace.test", "Acceptance Test production (backup)"),
+				),
+			},
+			// second step pushes content from a different staging report, keeping the production report ID
+			{
+				Config: config("staging_2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("powerbi_pbix.production", "report_id", &productionReportID),
+					resource.TestCheckResourceAttrPtr("powerbi_report_content.test", "id", &productionReportID),
+					resource.TestCheckResourceAttrPair("powerbi_report_content.test", "source_report_id", "powerbi_pbix.staging_2", "report_id"),
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test production"),
+					testCheckReportDoesNotExistsInWorkspace("powerbi_workspace.test", "Acceptance Test production (backup)"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,124 @@
+package powerbi
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccReport_basic(t *testing.T) {
+	var dataset1ID string
+	var dataset2ID string
+	var reportID string
+	var groupID string
+	workspaceSuffix := acctest.RandString(6)
+
+	datasetsConfig := fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_pbix" "dataset_1" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test dataset 1"
+		source = "./resource_pbix_dataset_only.pbix"
+		source_hash = "${filemd5("./resource_pbix_dataset_only.pbix")}"
+		skip_report = true
+	}
+
+	resource "powerbi_pbix" "dataset_2" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test dataset 2"
+		source = "./resource_pbix_dataset_only.pbix"
+		source_hash = "${filemd5("./resource_pbix_dataset_only.pbix")}"
+		skip_report = true
+	}
+	`, workspaceSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step deploys the report bound to the first dataset
+			{
+				Config: datasetsConfig + `
+				resource "powerbi_report" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test report"
+					source = "./resource_pbix_report_only.pbix"
+					dataset_id = "${powerbi_pbix.dataset_1.dataset_id}"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbix.dataset_1", "dataset_id", &dataset1ID),
+					set("powerbi_pbix.dataset_2", "dataset_id", &dataset2ID),
+					set("powerbi_report.test", "report_id", &reportID),
+					set("powerbi_workspace.test", "id", &groupID),
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test report"),
+					testCheckDatasetDoesNotExistsInWorkspace("powerbi_workspace.test", "Acceptance Test report"),
+					testCheckReportDataset("powerbi_report.test", &dataset1ID),
+				),
+			},
+			// second step rebinds the report to the second dataset in place
+			{
+				Config: datasetsConfig + `
+				resource "powerbi_report" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test report"
+					source = "./resource_pbix_report_only.pbix"
+					dataset_id = "${powerbi_pbix.dataset_2.dataset_id}"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("powerbi_report.test", "report_id", &reportID),
+					testCheckReportDataset("powerbi_report.test", &dataset2ID),
+				),
+			},
+			// third step deletes the report outside of terraform, which should be recreated
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*powerbiapi.Client)
+					client.DeleteReportInGroup(groupID, reportID)
+				},
+				Config: datasetsConfig + `
+				resource "powerbi_report" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test report"
+					source = "./resource_pbix_report_only.pbix"
+					dataset_id = "${powerbi_pbix.dataset_2.dataset_id}"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test report"),
+					testCheckResourceAttrNotEquals("powerbi_report.test", "report_id", &reportID),
+					testCheckReportDataset("powerbi_report.test", &dataset2ID),
+				),
+			},
+			// PBIX files containing a data model are rejected during plan
+			{
+				Config: datasetsConfig + `
+				resource "powerbi_report" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test report"
+					source = "./resource_pbix_report_only.pbix"
+					dataset_id = "${powerbi_pbix.dataset_2.dataset_id}"
+				}
+
+				resource "powerbi_report" "with_data_model" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test report with data model"
+					source = "./resource_pbix_test_sample1.pbix"
+					dataset_id = "${powerbi_pbix.dataset_2.dataset_id}"
+				}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("contains a data model"),
+			},
+		},
+	})
+}
@@ -2,6 +2,7 @@ package powerbi
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -31,6 +32,9 @@ func ResourceWorkspace() *schema.Resource {
 				Description: "Capacity ID to be assigned to workspace.",
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
 	}
 }
 
@@ -49,7 +53,7 @@ func createWorkspace(d *schema.ResourceData, meta interface{}) error {
 	d.SetId(resp.ID)
 
 	if capacityID != "" {
-		err := assignToCapacity(d, meta)
+		err := assignToCapacity(d, meta, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
 			return err
 		}
@@ -84,11 +88,7 @@ func readWorkspace(d *schema.ResourceData, meta interface{}) error {
 func updateWorkspace(d *schema.ResourceData, meta interface{}) error {
 
 	if d.HasChange("capacity_id") {
-		if capacityID := d.Get("capacity_id").(string); capacityID == "" {
-			d.Set("capacity_id", "00000000-0000-0000-0000-000000000000")
-		}
-
-		err := assignToCapacity(d, meta)
+		err := assignToCapacity(d, meta, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return err
 		}
@@ -103,10 +103,15 @@ func deleteWorkspace(d *schema.ResourceData, meta interface{}) error {
 	return client.DeleteGroup(d.Id())
 }
 
-func assignToCapacity(d *schema.ResourceData, meta interface{}) error {
+func assignToCapacity(d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
 	client := meta.(*powerbiapi.Client)
 
+	// assigning the empty GUID unassigns the workspace, moving it back to shared capacity
 	capacityID := d.Get("capacity_id").(string)
+	if capacityID == "" {
+		capacityID = "00000000-0000-0000-0000-000000000000"
+	}
+
 	if capacityID != "00000000-0000-0000-0000-000000000000" {
 		var capacityObjFound bool
 
@@ -134,5 +139,12 @@ func assignToCapacity(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	// capacity migration is asynchronous, so wait for it to complete before
+	// any dependent resources (such as PBIX imports) are deployed
+	_, err = client.WaitForCapacityAssignmentToSucceed(d.Id(), capacityID, timeout)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -61,6 +61,12 @@ func ResourceGroupUsers() *schema.Resource {
 				Required:     true,
 				ValidateFunc: validation.StringInSlice([]string{"User", "App", "Group"}, false),
 			},
+			"use_admin_api": {
+				Type:        schema.TypeBool,
+				Description: "If true, uses the Power BI admin API to manage workspace access. This allows tenant admins to manage workspaces they are not a member of.",
+				Optional:    true,
+				Default:     false,
+			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(5 * time.Minute),
@@ -78,18 +84,31 @@ func addGroupUser(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	client := meta.(*powerbiapi.Client)
-	err := client.AddGroupUser(groupID, powerbiapi.AddGroupUserRequest{
-		GroupUserAccessRight: d.Get("group_user_access_right").(string),
-		DisplayName:          d.Get("display_name").(string),
-		PrincipalType:        d.Get("principal_type").(string),
-		EmailAddress:         d.Get("email_address").(string),
-		Identifier:           d.Get("identifier").(string),
-	})
+	useAdminAPI := d.Get("use_admin_api").(bool)
+
+	var err error
+	if useAdminAPI {
+		err = client.AddUserAsAdmin(groupID, powerbiapi.AddUserAsAdminRequest{
+			GroupUserAccessRight: d.Get("group_user_access_right").(string),
+			DisplayName:          d.Get("display_name").(string),
+			PrincipalType:        d.Get("principal_type").(string),
+			EmailAddress:         d.Get("email_address").(string),
+			Identifier:           Identifier,
+		})
+	} else {
+		err = client.AddGroupUser(groupID, powerbiapi.AddGroupUserRequest{
+			GroupUserAccessRight: d.Get("group_user_access_right").(string),
+			DisplayName:          d.Get("display_name").(string),
+			PrincipalType:        d.Get("principal_type").(string),
+			EmailAddress:         d.Get("email_address").(string),
+			Identifier:           d.Get("identifier").(string),
+		})
+	}
 	if err != nil {
 		return err
 	}
 
-	workspaceObj, err := client.GetGroup(groupID)
+	workspaceName, err := getGroupUserWorkspaceName(client, useAdminAPI, groupID)
 	if err != nil {
 		return err
 	}
@@ -99,24 +118,25 @@ func addGroupUser(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	d.SetId(fmt.Sprintf("%s/%s", workspaceObj.Name, Identifier))
+	d.SetId(fmt.Sprintf("%s/%s", workspaceName, Identifier))
 	return nil
 }
 
 func readGroupUser(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*powerbiapi.Client)
+	useAdminAPI := d.Get("use_admin_api").(bool)
 
 	groupID := d.Get("workspace_id").(string)
 	var workspace string
 
 	if groupID == "" {
 		workspace = strings.SplitN(d.Id(), "/", 2)[0]
-		workspaceObj, err := client.GetGroupByName(workspace)
+		workspaceID, err := getGroupUserWorkspaceID(client, useAdminAPI, workspace)
 		if err != nil {
 			return err
 		}
-		groupID = workspaceObj.ID
+		groupID = workspaceID
 	}
 
 	Identifier := d.Get("identifier").(string)
@@ -130,13 +150,13 @@ func readGroupUser(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Could not find user identifier")
 	}
 
-	groupUsers, err := client.GetGroupUsers(groupID)
+	groupUsers, err := getGroupUsers(client, useAdminAPI, groupID)
 	if err != nil {
 		return err
 	}
 
-	if len(groupUsers.Value) >= 1 {
-		for _, apiOUTuserObj := range groupUsers.Value {
+	if len(groupUsers) >= 1 {
+		for _, apiOUTuserObj := range groupUsers {
 			if apiOUTuserObj.Identifier == Identifier {
 				d.Set("identifier", apiOUTuserObj.Identifier)
 				d.Set("group_user_access_right", apiOUTuserObj.GroupUserAccessRight)
@@ -154,27 +174,33 @@ func readGroupUser(d *schema.ResourceData, meta interface{}) error {
 func updateGroupUser(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*powerbiapi.Client)
+	useAdminAPI := d.Get("use_admin_api").(bool)
 
 	groupID := d.Get("workspace_id").(string)
 	var workspace string
 
 	if groupID == "" {
 		workspace = strings.SplitN(d.Id(), "/", 2)[0]
-		workspaceObj, err := client.GetGroupByName(workspace)
+		workspaceID, err := getGroupUserWorkspaceID(client, useAdminAPI, workspace)
 		if err != nil {
 			return err
 		}
-		groupID = workspaceObj.ID
+		groupID = workspaceID
 	}
 
 	if d.HasChange("group_user_access_right") {
-		err := client.UpdateGroupUser(groupID, powerbiapi.UpdateGroupUserRequest{
-			GroupUserAccessRight: d.Get("group_user_access_right").(string),
-			DisplayName:          d.Get("display_name").(string),
-			PrincipalType:        d.Get("principal_type").(string),
-			EmailAddress:         d.Get("email_address").(string),
-			Identifier:           d.Get("identifier").(string),
-		})
+		var err error
+		if useAdminAPI {
+			err = updateGroupUserAsAdmin(d, client, groupID)
+		} else {
+			err = client.UpdateGroupUser(groupID, powerbiapi.UpdateGroupUserRequest{
+				GroupUserAccessRight: d.Get("group_user_access_right").(string),
+				DisplayName:          d.Get("display_name").(string),
+				PrincipalType:        d.Get("principal_type").(string),
+				EmailAddress:         d.Get("email_address").(string),
+				Identifier:           d.Get("identifier").(string),
+			})
+		}
 		if err != nil {
 			return err
 		}
@@ -188,17 +214,18 @@ func updateGroupUser(d *schema.ResourceData, meta interface{}) error {
 func deleteGroupUser(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*powerbiapi.Client)
+	useAdminAPI := d.Get("use_admin_api").(bool)
 
 	groupID := d.Get("workspace_id").(string)
 	var workspace string
 
 	if groupID == "" {
 		workspace = strings.SplitN(d.Id(), "/", 2)[0]
-		workspaceObj, err := client.GetGroupByName(workspace)
+		workspaceID, err := getGroupUserWorkspaceID(client, useAdminAPI, workspace)
 		if err != nil {
 			return err
 		}
-		groupID = workspaceObj.ID
+		groupID = workspaceID
 	}
 
 	Identifier := d.Get("identifier").(string)
@@ -212,5 +239,100 @@ func deleteGroupUser(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Could not find user identifier")
 	}
 
+	if useAdminAPI {
+		return client.DeleteUserAsAdmin(groupID, Identifier)
+	}
 	return client.DeleteUserInGroup(groupID, Identifier)
 }
+
+func updateGroupUserAsAdmin(d *schema.ResourceData, client *powerbiapi.Client, groupID string) error {
+
+	Identifier := d.Get("identifier").(string)
+	if Identifier == "" {
+		Identifier = d.Get("email_address").(string)
+	}
+
+	request := powerbiapi.AddUserAsAdminRequest{
+		GroupUserAccessRight: d.Get("group_user_access_right").(string),
+		DisplayName:          d.Get("display_name").(string),
+		PrincipalType:        d.Get("principal_type").(string),
+		EmailAddress:         d.Get("email_address").(string),
+		Identifier:           Identifier,
+	}
+
+	err := client.AddUserAsAdmin(groupID, request)
+	if !isHTTPAlreadyExistsError(err) {
+		return err
+	}
+
+	// the admin API has no update endpoint, so a user that already exists is removed and then granted the new access right
+	err = client.DeleteUserAsAdmin(groupID, Identifier)
+	if err != nil && !isHTTP404Error(err) {
+		return err
+	}
+
+	err = client.AddUserAsAdmin(groupID, request)
+	if err != nil {
+		return fmt.Errorf("The access of '%s' to workspace '%s' was removed in order to change it, but granting the new access right failed. The user currently has no access to the workspace: %s", Identifier, groupID, err)
+	}
+	return nil
+}
+
+func getGroupUserWorkspaceID(client *powerbiapi.Client, useAdminAPI bool, workspaceName string) (string, error) {
+	if useAdminAPI {
+		workspaceObj, err := client.GetGroupByNameAsAdmin(workspaceName)
+		if err != nil {
+			return "", err
+		}
+		if workspaceObj == nil {
+			return "", fmt.Errorf("Could not find workspace '%s'", workspaceName)
+		}
+		return workspaceObj.ID, nil
+	}
+
+	workspaceObj, err := client.GetGroupByName(workspaceName)
+	if err != nil {
+		return "", err
+	}
+	if workspaceObj == nil {
+		return "", fmt.Errorf("Could not find workspace '%s'", workspaceName)
+	}
+	return workspaceObj.ID, nil
+}
+
+func getGroupUserWorkspaceName(client *powerbiapi.Client, useAdminAPI bool, groupID string) (string, error) {
+	if useAdminAPI {
+		workspaceObj, err := client.GetGroupAsAdmin(groupID)
+		if err != nil {
+			return "", err
+		}
+		return workspaceObj.Name, nil
+	}
+
+	workspaceObj, err := client.GetGroup(groupID)
+	if err != nil {
+		return "", err
+	}
+	if workspaceObj == nil {
+		return "", fmt.Errorf("Could not find workspace '%s'", groupID)
+	}
+	return workspaceObj.Name, nil
+}
+
+func getGroupUsers(client *powerbiapi.Client, useAdminAPI bool, groupID string) ([]powerbiapi.GetGroupUsersResponseItem, error) {
+	if useAdminAPI {
+		groupUsers, err := client.GetGroupUsersAsAdmin(groupID)
+		if err != nil {
+			return nil, err
+		}
+		return genericMap(groupUsers.Value, func(user powerbiapi.GetGroupUsersAsAdminResponseItem) powerbiapi.GetGroupUsersResponseItem {
+			return powerbiapi.GetGroupUsersResponseItem(user)
+		}).([]powerbiapi.GetGroupUsersResponseItem), nil
+	}
+
+	groupUsers, err := client.GetGroupUsers(groupID)
+	if err != nil {
+		return nil, err
+	}
+	return groupUsers.Value, nil
+}
@@ -57,6 +57,57 @@ func TestAccWorkspaceAccess_basic(t *testing.T) {
 	})
 }
 
+func TestAccWorkspaceAccess_adminAPI(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+	secondaryUsername := os.Getenv("POWERBI_SECONDARY_USERNAME")
+
+	config := func(accessRight string) string {
+		return fmt.Sprintf(`
+		resource "powerbi_workspace" "test" {
+			name = "Acceptance Test Workspace %s"
+		}
+
+		resource "powerbi_workspace_access" "test" {
+			workspace_id = "${powerbi_workspace.test.id}"
+			group_user_access_right = "%s"
+			email_address = "%s"
+			principal_type = "User"
+			use_admin_api = true
+		}
+		`, workspaceSuffix, accessRight, secondaryUsername)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if secondaryUsername == "" {
+				t.Fatal("POWERBI_SECONDARY_USERNAME must be set for workspace access acceptance tests")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step creates the resource through the admin API
+			{
+				Config: config("Member"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGroupUserExistsInWorkspace("powerbi_workspace.test", secondaryUsername),
+					resource.TestCheckResourceAttr("powerbi_workspace_access.test", "group_user_access_right", "Member"),
+					resource.TestCheckResourceAttr("powerbi_workspace_access.test", "id", fmt.Sprintf("Acceptance Test Workspace %s/%s", workspaceSuffix, secondaryUsername)),
+				),
+			},
+			// second step updates the access right through the admin API
+			{
+				Config: config("Viewer"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGroupUserExistsInWorkspace("powerbi_workspace.test", secondaryUsername),
+					resource.TestCheckResourceAttr("powerbi_workspace_access.test", "group_user_access_right", "Viewer"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccWorkspaceAccess_validation(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
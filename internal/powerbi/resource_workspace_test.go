@@ -105,6 +105,7 @@ func TestAccWorkspace_capacity(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet("powerbi_workspace.test", "id"),
 					resource.TestCheckResourceAttr("powerbi_workspace.test", "capacity_id", premiumCapacityID),
+					testCheckWorkspaceCapacityAssignment("powerbi_workspace.test", premiumCapacityID),
 				),
 			},
 			// third step unassigns capacity id
@@ -117,6 +118,20 @@ func TestAccWorkspace_capacity(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet("powerbi_workspace.test", "id"),
 					resource.TestCheckResourceAttr("powerbi_workspace.test", "capacity_id", ""),
+					testCheckWorkspaceCapacityAssignment("powerbi_workspace.test", ""),
+				),
+			},
+			// fourth step assigns the capacity again, right after the previous unassignment completed
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+					capacity_id = "%s"
+				}
+				`, workspaceSuffix, premiumCapacityID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_workspace.test", "capacity_id", premiumCapacityID),
+					testCheckWorkspaceCapacityAssignment("powerbi_workspace.test", premiumCapacityID),
 				),
 			},
 			// final step checks importing the current state we reached in the step above
@@ -230,3 +245,43 @@ func testAccCheckPowerbiWorkspaceDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+// testCheckWorkspaceCapacityAssignment checks the workspace capacity, and that the capacity assignment has completed for it
+func testCheckWorkspaceCapacityAssignment(rn string, expectedCapacityID string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		client := testAccProvider.Meta().(*powerbiapi.Client)
+		workspace, err := client.GetGroup(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if workspace == nil {
+			return fmt.Errorf("workspace with ID '%s' does not exist", rs.Primary.ID)
+		}
+
+		if expectedCapacityID == "" {
+			if workspace.IsOnDedicatedCapacity {
+				return fmt.Errorf("workspace is on capacity '%s' was expecting shared capacity", workspace.CapacityID)
+			}
+			return nil
+		}
+
+		if !strings.EqualFold(workspace.CapacityID, expectedCapacityID) {
+			return fmt.Errorf("workspace is on capacity '%s' was expecting '%s'", workspace.CapacityID, expectedCapacityID)
+		}
+
+		status, err := client.GetCapacityAssignmentStatus(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if status.Status != "CompletedSuccessfully" || !strings.EqualFold(status.CapacityID, expectedCapacityID) {
+			return fmt.Errorf("capacity assignment has status '%s' for capacity '%s' was expecting 'CompletedSuccessfully' for '%s'", status.Status, status.CapacityID, expectedCapacityID)
+		}
+
+		return nil
+	}
+}
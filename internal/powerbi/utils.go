@@ -3,6 +3,7 @@ package powerbi
 import (
 	"net/url"
 	"reflect"
+	"strings"
 
 	"github.com/MWS-TAI/terraform-provider-powerbi/internal/powerbiapi"
 )
@@ -41,6 +42,13 @@ func emptyStringToNil(input string) *string {
 	return &input
 }
 
+func nilToEmptyString(input *string) string {
+	if input == nil {
+		return ""
+	}
+	return *input
+}
+
 func isHTTP404Error(err error) bool {
 	if httpErr, isHTTPErr := toHTTPUnsuccessfulError(err); isHTTPErr && httpErr.Response.StatusCode == 404 {
 		return true
@@ -55,6 +63,21 @@ func isHTTP401Error(err error) bool {
 	return false
 }
 
+func isHTTP403Error(err error) bool {
+	if httpErr, isHTTPErr := toHTTPUnsuccessfulError(err); isHTTPErr && httpErr.Response.StatusCode == 403 {
+		return true
+	}
+	return false
+}
+
+// isHTTPAlreadyExistsError checks whether the API rejected a request because the item being added already exists
+func isHTTPAlreadyExistsError(err error) bool {
+	if httpErr, isHTTPErr := toHTTPUnsuccessfulError(err); isHTTPErr && (httpErr.Response.StatusCode == 400 || httpErr.Response.StatusCode == 409) {
+		return httpErr.ErrorBody != nil && strings.Contains(httpErr.ErrorBody.Code, "AlreadyExists")
+	}
+	return false
+}
+
 func toHTTPUnsuccessfulError(err error) (*powerbiapi.HTTPUnsuccessfulError, bool) {
 	if err == nil {
 		return nil, false
@@ -3,6 +3,8 @@ package powerbiapi
 import (
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
 )
 
 // UpdateGroupAsAdminRequest represents the request to the UpdateGroupAsAdmin API
@@ -10,9 +12,137 @@ type UpdateGroupAsAdminRequest struct {
 	Name string `json:"name"`
 }
 
+// GetGroupsAsAdminResponse represents the response from the GetGroupsAsAdmin API
+type GetGroupsAsAdminResponse struct {
+	Value []GetGroupsAsAdminResponseItem
+}
+
+// GetGroupsAsAdminResponseItem represents an item returned within GetGroupsAsAdminResponse
+type GetGroupsAsAdminResponseItem struct {
+	ID                    string
+	IsOnDedicatedCapacity bool
+	Name                  string
+	CapacityID            string
+	Type                  string
+	State                 string
+}
+
+// GetGroupAsAdminResponse represents the response from the GetGroupAsAdmin API
+type GetGroupAsAdminResponse struct {
+	ID                    string
+	IsOnDedicatedCapacity bool
+	Name                  string
+	CapacityID            string
+	Type                  string
+	State                 string
+}
+
+// GetGroupUsersAsAdminResponse represents the list of users that have access to the specified workspace.
+type GetGroupUsersAsAdminResponse struct {
+	Value []GetGroupUsersAsAdminResponseItem
+}
+
+// GetGroupUsersAsAdminResponseItem represents a single user details.
+type GetGroupUsersAsAdminResponseItem struct {
+	DisplayName          string
+	EmailAddress         string
+	GroupUserAccessRight string
+	Identifier           string
+	PrincipalType        string
+}
+
+// AddUserAsAdminRequest represents details when adding a group user as an admin.
+type AddUserAsAdminRequest struct {
+	DisplayName          string `json:"displayName,omitempty"`
+	EmailAddress         string `json:"emailAddress,omitempty"`
+	GroupUserAccessRight string `json:"groupUserAccessRight"`
+	Identifier           string `json:"identifier"`
+	PrincipalType        string `json:"principalType"`
+}
+
 // UpdateGroupAsAdmin updates a workspace
 func (client *Client) UpdateGroupAsAdmin(groupID string, request UpdateGroupAsAdminRequest) error {
 
 	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/admin/groups/%s", url.PathEscape(groupID))
 	return client.doJSON("PATCH", url, request, nil)
 }
+
+// GetGroupsAsAdmin returns a list of workspaces for the organization.
+func (client *Client) GetGroupsAsAdmin(filter string, top int, skip int) (*GetGroupsAsAdminResponse, error) {
+
+	// the admin API requires $top to always be provided
+	if top <= 0 {
+		top = 5000
+	}
+
+	queryParams := url.Values{}
+	if filter != "" {
+		queryParams.Add("$filter", filter)
+	}
+	queryParams.Add("$top", strconv.Itoa(top))
+	if skip > 0 {
+		queryParams.Add("$skip", strconv.Itoa(skip))
+	}
+
+	var respObj GetGroupsAsAdminResponse
+	err := client.doJSON("GET", "https://api.powerbi.com/v1.0/myorg/admin/groups?"+queryParams.Encode(), nil, &respObj)
+
+	return &respObj, err
+}
+
+// GetGroupAsAdmin returns a single workspace, regardless of whether the caller is a member of it.
+func (client *Client) GetGroupAsAdmin(groupID string) (*GetGroupAsAdminResponse, error) {
+
+	var respObj GetGroupAsAdminResponse
+	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/admin/groups/%s", url.PathEscape(groupID))
+	err := client.doJSON("GET", url, nil, &respObj)
+
+	return &respObj, err
+}
+
+// GetGroupByNameAsAdmin returns a single workspace, regardless of whether the caller is a member of it.
+func (client *Client) GetGroupByNameAsAdmin(groupName string) (*GetGroupAsAdminResponse, error) {
+
+	groups, err := client.GetGroupsAsAdmin(fmt.Sprintf("name eq '%s'", strings.ReplaceAll(groupName, "'", "''")), -1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups.Value) == 0 {
+		return nil, nil
+	}
+
+	singleGroup := &groups.Value[0]
+	return &GetGroupAsAdminResponse{
+		ID:                    singleGroup.ID,
+		IsOnDedicatedCapacity: singleGroup.IsOnDedicatedCapacity,
+		Name:                  singleGroup.Name,
+		CapacityID:            singleGroup.CapacityID,
+		Type:                  singleGroup.Type,
+		State:                 singleGroup.State,
+	}, nil
+}
+
+// GetGroupUsersAsAdmin returns a list of users that have access to the specified workspace.
+func (client *Client) GetGroupUsersAsAdmin(groupID string) (*GetGroupUsersAsAdminResponse, error) {
+
+	var respObj GetGroupUsersAsAdminResponse
+	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/admin/groups/%s/users", url.PathEscape(groupID))
+	err := client.doJSON("GET", url, nil, &respObj)
+
+	return &respObj, err
+}
+
+// AddUserAsAdmin grants user permissions to the specified workspace.
+func (client *Client) AddUserAsAdmin(groupID string, request AddUserAsAdminRequest) error {
+
+	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/admin/groups/%s/users", url.PathEscape(groupID))
+	return client.doJSON("POST", url, &request, nil)
+}
+
+// DeleteUserAsAdmin removes user permissions from the specified workspace.
+func (client *Client) DeleteUserAsAdmin(groupID string, user string) error {
+
+	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/admin/groups/%s/users/%s", url.PathEscape(groupID), url.PathEscape(user))
+	return client.doJSON("DELETE", url, nil, nil)
+}
@@ -3,6 +3,8 @@ package powerbiapi
 import (
 	"fmt"
 	"net/url"
+	"strings"
+	"time"
 )
 
 // GroupAssignToCapacityRequest represents the request for Assigning capacity to group API.
@@ -26,6 +28,15 @@ type GetCapacitiesResponseItem struct {
 	CapacityUserAccessRight string
 }
 
+// GetCapacityAssignmentStatusResponse represents the response of the get capacity assignment status API.
+type GetCapacityAssignmentStatusResponse struct {
+	Status     string
+	ActivityID string
+	StartTime  *time.Time
+	EndTime    *time.Time
+	CapacityID string
+}
+
 //CapacityAdmins represents the list of capacity admins.
 type CapacityAdmins string
 
@@ -44,3 +55,49 @@ func (client *Client) GetCapacities() (*GetCapacitiesResponse, error) {
 
 	return &respObj, err
 }
+
+// GetCapacityAssignmentStatus gets the status of the assignment to capacity operation of the specified workspace.
+func (client *Client) GetCapacityAssignmentStatus(groupID string) (*GetCapacityAssignmentStatusResponse, error) {
+	var respObj GetCapacityAssignmentStatusResponse
+	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/CapacityAssignmentStatus", url.PathEscape(groupID))
+	err := client.doJSON("GET", url, nil, &respObj)
+
+	return &respObj, err
+}
+
+// WaitForCapacityAssignmentToSucceed waits until the assignment of the specified workspace to the specified capacity completes.
+// Until the assignment is picked up, the status may still describe a previous assignment, so statuses for other capacities are treated as pending
+func (client *Client) WaitForCapacityAssignmentToSucceed(groupID string, capacityID string, timeout time.Duration) (*GetCapacityAssignmentStatusResponse, error) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	started := time.Now()
+	for {
+		status, err := client.GetCapacityAssignmentStatus(groupID)
+		if err != nil {
+			return nil, err
+		}
+
+		if isCapacityAssignmentStatusFor(status, capacityID) {
+			if status.Status == "CompletedSuccessfully" {
+				return status, nil
+			} else if status.Status != "Pending" && status.Status != "InProgress" {
+				return status, fmt.Errorf("Capacity assignment completed with invalid state '%s' (capacity '%s', activity '%s')", status.Status, status.CapacityID, status.ActivityID)
+			}
+		}
+
+		now := <-ticker.C
+		if now.Sub(started) > timeout {
+			return nil, fmt.Errorf("Timed out waiting for capacity assignment to complete. Capacity assignment taking longer than %v seconds", timeout.Seconds())
+		}
+	}
+}
+
+// isCapacityAssignmentStatusFor checks whether the assignment status belongs to an assignment to the specified capacity.
+// Unassigning uses the empty GUID, for which the status may not include a capacity
+func isCapacityAssignmentStatusFor(status *GetCapacityAssignmentStatusResponse, capacityID string) bool {
+	if strings.EqualFold(status.CapacityID, capacityID) {
+		return true
+	}
+	return capacityID == "00000000-0000-0000-0000-000000000000" && status.CapacityID == ""
+}
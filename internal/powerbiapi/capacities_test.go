@@ -0,0 +1,92 @@
+package powerbiapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type capacityAssignmentStatusRoundTripper struct {
+	responses []string
+	calls     int
+}
+
+func (rt *capacityAssignmentStatusRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := rt.responses[rt.calls]
+	if rt.calls < len(rt.responses)-1 {
+		rt.calls++
+	}
+	return &http.Response{
+		StatusCode: 200,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func newCapacityAssignmentStatusClient(responses ...string) *Client {
+	return &Client{&http.Client{Transport: &capacityAssignmentStatusRoundTripper{responses: responses}}}
+}
+
+func TestWaitForCapacityAssignmentToSucceed_ignoresPreviousAssignment(t *testing.T) {
+	client := newCapacityAssignmentStatusClient(
+		`{"status": "CompletedSuccessfully", "capacityId": "previous-capacity"}`,
+		`{"status": "InProgress", "capacityId": "new-capacity"}`,
+		`{"status": "CompletedSuccessfully", "capacityId": "NEW-CAPACITY"}`,
+	)
+
+	status, err := client.WaitForCapacityAssignmentToSucceed("group", "new-capacity", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.CapacityID != "NEW-CAPACITY" {
+		t.Fatalf("Expected the status of the new assignment, found capacity '%s'", status.CapacityID)
+	}
+}
+
+func TestWaitForCapacityAssignmentToSucceed_ignoresPreviousFailedAssignment(t *testing.T) {
+	client := newCapacityAssignmentStatusClient(
+		`{"status": "AssignmentFailed", "capacityId": "previous-capacity"}`,
+		`{"status": "CompletedSuccessfully", "capacityId": "new-capacity"}`,
+	)
+
+	_, err := client.WaitForCapacityAssignmentToSucceed("group", "new-capacity", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitForCapacityAssignmentToSucceed_failed(t *testing.T) {
+	client := newCapacityAssignmentStatusClient(
+		`{"status": "AssignmentFailed", "capacityId": "new-capacity", "activityId": "activity"}`,
+	)
+
+	_, err := client.WaitForCapacityAssignmentToSucceed("group", "new-capacity", time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "invalid state 'AssignmentFailed'") {
+		t.Fatalf("Expected an error for the failed assignment, found %v", err)
+	}
+}
+
+func TestWaitForCapacityAssignmentToSucceed_unassign(t *testing.T) {
+	client := newCapacityAssignmentStatusClient(
+		`{"status": "CompletedSuccessfully"}`,
+	)
+
+	_, err := client.WaitForCapacityAssignmentToSucceed("group", "00000000-0000-0000-0000-000000000000", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitForCapacityAssignmentToSucceed_timeout(t *testing.T) {
+	client := newCapacityAssignmentStatusClient(
+		`{"status": "CompletedSuccessfully", "capacityId": "previous-capacity"}`,
+	)
+
+	_, err := client.WaitForCapacityAssignmentToSucceed("group", "new-capacity", time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "Timed out") {
+		t.Fatalf("Expected a timeout while the status belongs to a previous assignment, found %v", err)
+	}
+}
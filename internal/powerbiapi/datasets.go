@@ -3,6 +3,7 @@ package powerbiapi
 import (
 	"fmt"
 	"net/url"
+	"time"
 )
 
 // GetDatasetInGroupResponse represents the details when getting a datasets in a group.
@@ -69,15 +70,22 @@ type GetDatasourcesInGroupResponseItem struct {
 	DatasourceType    string
 	GatewayID         string
 	Name              string
-	CopnnectionString string
+	ConnectionString  string
 	ConnectionDetails GetDatasourcesInGroupResponseItemConnectionDetails
 }
 
 // GetDatasourcesInGroupResponseItemConnectionDetails represents connection details for a single datasource
 type GetDatasourcesInGroupResponseItemConnectionDetails struct {
-	Database *string
-	Server   *string
-	URL      *string
+	Database     *string `json:"database,omitempty"`
+	Server       *string `json:"server,omitempty"`
+	URL          *string `json:"url,omitempty"`
+	Path         *string `json:"path,omitempty"`
+	Kind         *string `json:"kind,omitempty"`
+	Account      *string `json:"account,omitempty"`
+	Domain       *string `json:"domain,omitempty"`
+	EmailAddress *string `json:"emailAddress,omitempty"`
+	LoginServer  *string `json:"loginServer,omitempty"`
+	ClassInfo    *string `json:"classInfo,omitempty"`
 }
 
 // UpdateDatasourcesInGroupRequest represents the request to update datasources
@@ -93,15 +101,29 @@ type UpdateDatasourcesInGroupRequestItem struct {
 
 // UpdateDatasourcesInGroupRequestItemDatasourceSelector represents a query to select a datasource
 type UpdateDatasourcesInGroupRequestItemDatasourceSelector struct {
-	DatasourceType    string
-	ConnectionDetails UpdateDatasourcesInGroupRequestItemConnectionDetails
+	DatasourceType    string                                               `json:"datasourceType,omitempty"`
+	ConnectionDetails UpdateDatasourcesInGroupRequestItemConnectionDetails `json:"connectionDetails"`
+	DatasourceID      string                                               `json:"datasourceId,omitempty"`
+	GatewayID         string                                               `json:"gatewayId,omitempty"`
 }
 
 // UpdateDatasourcesInGroupRequestItemConnectionDetails represents connection details for a single datasource
 type UpdateDatasourcesInGroupRequestItemConnectionDetails struct {
-	Database *string
-	Server   *string
-	URL      *string
+	Database     *string `json:"database,omitempty"`
+	Server       *string `json:"server,omitempty"`
+	URL          *string `json:"url,omitempty"`
+	Path         *string `json:"path,omitempty"`
+	Kind         *string `json:"kind,omitempty"`
+	Account      *string `json:"account,omitempty"`
+	Domain       *string `json:"domain,omitempty"`
+	EmailAddress *string `json:"emailAddress,omitempty"`
+	LoginServer  *string `json:"loginServer,omitempty"`
+	ClassInfo    *string `json:"classInfo,omitempty"`
+}
+
+// UpdateDatasetNameInGroupRequest represents the request to rename a dataset
+type UpdateDatasetNameInGroupRequest struct {
+	DisplayName string `json:"displayName"`
 }
 
 // GetRefreshScheduleInGroupResponse represents the response to getting a refresh schedule
@@ -127,6 +149,27 @@ type UpdateRefreshScheduleInGroupRequestValue struct {
 	NotifyOption    *string   `json:"notifyOption,omitempty"`
 }
 
+// RefreshDatasetInGroupRequest represents the request to trigger a dataset refresh
+type RefreshDatasetInGroupRequest struct {
+	NotifyOption string `json:"notifyOption"`
+}
+
+// GetRefreshHistoryInGroupResponse represents the refresh history of a dataset
+type GetRefreshHistoryInGroupResponse struct {
+	Value []GetRefreshHistoryInGroupResponseItem
+}
+
+// GetRefreshHistoryInGroupResponseItem represents a single refresh in the refresh history of a dataset
+type GetRefreshHistoryInGroupResponseItem struct {
+	RequestID            string `json:"requestId"`
+	ID                   int64  `json:"id"`
+	RefreshType          string
+	StartTime            string
+	EndTime              string
+	Status               string
+	ServiceExceptionJSON string `json:"serviceExceptionJson"`
+}
+
 // GetDatasetInGroup returns a dataset within the specified group.
 func (client *Client) GetDatasetInGroup(groupID string, datasetID string) (*GetDatasetInGroupResponse, error) {
 
@@ -165,6 +208,16 @@ func (client *Client) TakeOverDatasetInGroup(groupID string, datasetID string) e
 	return err
 }
 
+// UpdateDatasetNameInGroup renames a dataset that exists within a group, keeping its ID.
+// The Power BI API has no rename endpoint, so this uses the Fabric update semantic model API.
+func (client *Client) UpdateDatasetNameInGroup(groupID string, datasetID string, request UpdateDatasetNameInGroupRequest) error {
+
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/semanticModels/%s", url.PathEscape(groupID), url.PathEscape(datasetID))
+	err := client.doJSON("PATCH", url, &request, nil)
+
+	return err
+}
+
 // GetParametersInGroup gets parameters in a dataset that exists within a group.
 func (client *Client) GetParametersInGroup(groupID string, datasetID string) (*GetParametersInGroupResponse, error) {
 
@@ -221,3 +274,73 @@ func (client *Client) UpdateRefreshScheduleInGroup(groupID string, datasetID str
 
 	return err
 }
+
+// RefreshDatasetInGroup triggers a refresh of a dataset that exists within a group.
+func (client *Client) RefreshDatasetInGroup(groupID string, datasetID string, request RefreshDatasetInGroupRequest) error {
+
+	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/datasets/%s/refreshes", url.PathEscape(groupID), url.PathEscape(datasetID))
+	err := client.doJSON("POST", url, &request, nil)
+
+	return err
+}
+
+// GetRefreshHistoryInGroup returns the most recent refreshes of a dataset that exists within a group.
+func (client *Client) GetRefreshHistoryInGroup(groupID string, datasetID string, top int) (*GetRefreshHistoryInGroupResponse, error) {
+
+	var respObj GetRefreshHistoryInGroupResponse
+	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/datasets/%s/refreshes?$top=%d", url.PathEscape(groupID), url.PathEscape(datasetID), top)
+	err := client.doJSON("GET", url, nil, &respObj)
+
+	return &respObj, err
+}
+
+// RefreshDatasetInGroupAndWait triggers a refresh of a dataset and waits until that refresh completes
+func (client *Client) RefreshDatasetInGroupAndWait(groupID string, datasetID string, timeout time.Duration) error {
+
+	// the refresh request does not return an identifier, so the refresh started here is
+	// identified as the most recent refresh that differs from the one before triggering
+	previousRequestID := ""
+	history, err := client.GetRefreshHistoryInGroup(groupID, datasetID, 1)
+	if err != nil {
+		return err
+	}
+	if len(history.Value) > 0 {
+		previousRequestID = history.Value[0].RequestID
+	}
+
+	err = client.RefreshDatasetInGroup(groupID, datasetID, RefreshDatasetInGroupRequest{
+		NotifyOption: "NoNotification",
+	})
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	started := time.Now()
+	for {
+		now := <-ticker.C
+		if now.Sub(started) > timeout {
+			return fmt.Errorf("Timed out waiting for dataset '%s' to refresh. Refresh taking longer than %v seconds", datasetID, timeout.Seconds())
+		}
+
+		history, err := client.GetRefreshHistoryInGroup(groupID, datasetID, 1)
+		if err != nil {
+			return err
+		}
+		if len(history.Value) == 0 || history.Value[0].RequestID == previousRequestID {
+			continue
+		}
+
+		refresh := history.Value[0]
+		switch refresh.Status {
+		case "Completed":
+			return nil
+		case "Unknown", "NotStarted":
+			continue
+		default:
+			return fmt.Errorf("Refresh of dataset '%s' completed with status '%s': %s", datasetID, refresh.Status, refresh.ServiceExceptionJSON)
+		}
+	}
+}
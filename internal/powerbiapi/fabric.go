@@ -0,0 +1,148 @@
+package powerbiapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ItemDefinition represents the definition of a Fabric item, such as a semantic model or report, as a set of files
+type ItemDefinition struct {
+	Parts []ItemDefinitionPart `json:"parts"`
+}
+
+// ItemDefinitionPart represents a single file within an item definition
+type ItemDefinitionPart struct {
+	Path        string `json:"path"`
+	Payload     string `json:"payload"`
+	PayloadType string `json:"payloadType"`
+}
+
+// CreateItemWithDefinitionRequest represents the request to create a Fabric item from its definition
+type CreateItemWithDefinitionRequest struct {
+	DisplayName string         `json:"displayName"`
+	Definition  ItemDefinition `json:"definition"`
+}
+
+// CreateItemWithDefinitionResponse represents the response from creating a Fabric item
+type CreateItemWithDefinitionResponse struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Type        string `json:"type"`
+	WorkspaceID string `json:"workspaceId"`
+}
+
+// UpdateItemDefinitionRequest represents the request to replace the definition of a Fabric item
+type UpdateItemDefinitionRequest struct {
+	Definition ItemDefinition `json:"definition"`
+}
+
+// GetOperationStateResponse represents the state of a Fabric long running operation
+type GetOperationStateResponse struct {
+	Status          string
+	PercentComplete int
+	Error           *ErrorBody
+}
+
+// CreateSemanticModelWithDefinition creates a semantic model from its definition, such as TMDL files or model.bim.
+// This uses the Fabric API and waits for the creation to complete
+func (client *Client) CreateSemanticModelWithDefinition(groupID string, request CreateItemWithDefinitionRequest, timeout time.Duration) (*CreateItemWithDefinitionResponse, error) {
+
+	var respObj CreateItemWithDefinitionResponse
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/semanticModels", url.PathEscape(groupID))
+	err := client.doFabricLongRunningJSON("POST", url, &request, &respObj, timeout)
+
+	return &respObj, err
+}
+
+// UpdateSemanticModelDefinition replaces the definition of a semantic model, keeping its ID.
+// This uses the Fabric API and waits for the update to complete
+func (client *Client) UpdateSemanticModelDefinition(groupID string, semanticModelID string, request UpdateItemDefinitionRequest, timeout time.Duration) error {
+
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/semanticModels/%s/updateDefinition", url.PathEscape(groupID), url.PathEscape(semanticModelID))
+	return client.doFabricLongRunningJSON("POST", url, &request, nil, timeout)
+}
+
+// CreateReportWithDefinition creates a report from its PBIR definition.
+// This uses the Fabric API and waits for the creation to complete
+func (client *Client) CreateReportWithDefinition(groupID string, request CreateItemWithDefinitionRequest, timeout time.Duration) (*CreateItemWithDefinitionResponse, error) {
+
+	var respObj CreateItemWithDefinitionResponse
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/reports", url.PathEscape(groupID))
+	err := client.doFabricLongRunningJSON("POST", url, &request, &respObj, timeout)
+
+	return &respObj, err
+}
+
+// UpdateReportDefinition replaces the definition of a report, keeping its ID.
+// This uses the Fabric API and waits for the update to complete
+func (client *Client) UpdateReportDefinition(groupID string, reportID string, request UpdateItemDefinitionRequest, timeout time.Duration) error {
+
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/reports/%s/updateDefinition", url.PathEscape(groupID), url.PathEscape(reportID))
+	return client.doFabricLongRunningJSON("POST", url, &request, nil, timeout)
+}
+
+// GetOperationState gets the state of a Fabric long running operation
+func (client *Client) GetOperationState(operationID string) (*GetOperationStateResponse, error) {
+
+	var respObj GetOperationStateResponse
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/operations/%s", url.PathEscape(operationID))
+	err := client.doJSON("GET", url, nil, &respObj)
+
+	return &respObj, err
+}
+
+// doFabricLongRunningJSON calls a Fabric API that may complete asynchronously.
+// When the API accepts the request as a long running operation, the operation is polled until it completes
+// and the response is read from the operation result
+func (client *Client) doFabricLongRunningJSON(method string, url string, body interface{}, response interface{}, timeout time.Duration) error {
+
+	httpRequest, err := newJSONRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+
+	httpResponse, err := client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+
+	if httpResponse.StatusCode != http.StatusAccepted {
+		return newJSONResponse(httpResponse, response)
+	}
+	httpResponse.Body.Close()
+
+	operationID := httpResponse.Header.Get("x-ms-operation-id")
+	if operationID == "" {
+		return fmt.Errorf("Long running operation for '%s' did not return an operation ID", url)
+	}
+
+	started := time.Now()
+	for {
+		time.Sleep(readRetryAfter(httpResponse, 5*time.Second))
+
+		state, err := client.GetOperationState(operationID)
+		if err != nil {
+			return err
+		}
+
+		switch state.Status {
+		case "Succeeded":
+			if response == nil {
+				return nil
+			}
+			resultURL := fmt.Sprintf("https://api.fabric.microsoft.com/v1/operations/%s/result", operationID)
+			return client.doJSON("GET", resultURL, nil, response)
+		case "Failed":
+			if state.Error != nil {
+				return fmt.Errorf("Long running operation '%s' failed with code '%s': %s", operationID, state.Error.Code, state.Error.Message)
+			}
+			return fmt.Errorf("Long running operation '%s' failed", operationID)
+		}
+
+		if time.Since(started) > timeout {
+			return fmt.Errorf("Timed out waiting for long running operation '%s' to complete. Operation taking longer than %v seconds", operationID, timeout.Seconds())
+		}
+	}
+}
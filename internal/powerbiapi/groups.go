@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // CreateGroupRequest represents the request for the CreateGroup API
@@ -40,6 +41,20 @@ type GetGroupResponse struct {
 	CapacityID            string
 }
 
+// GetGroupsWithRolesResponse represents the response from listing workspaces through the Fabric API
+type GetGroupsWithRolesResponse struct {
+	Value             []GetGroupsWithRolesResponseItem
+	ContinuationToken string
+}
+
+// GetGroupsWithRolesResponseItem represents an item returned within GetGroupsWithRolesResponse
+type GetGroupsWithRolesResponseItem struct {
+	ID          string
+	DisplayName string
+	Type        string
+	CapacityID  string
+}
+
 //GetGroupUsersResponse represents list of users that have access to the specified workspace.
 type GetGroupUsersResponse struct {
 	Value []GetGroupUsersResponseItem
@@ -148,6 +163,33 @@ func (client *Client) GetGroupByName(groupName string) (*GetGroupResponse, error
 	}, nil
 }
 
+// GetGroupsWithRoles returns all workspaces in which the caller has any of the specified roles, such as Admin, Member, Contributor or Viewer.
+// The Power BI API does not expose the caller's role, so this uses the Fabric list workspaces API
+func (client *Client) GetGroupsWithRoles(roles []string) (*GetGroupsWithRolesResponse, error) {
+
+	var groups GetGroupsWithRolesResponse
+	continuationToken := ""
+	for {
+		queryParams := url.Values{}
+		queryParams.Add("roles", strings.Join(roles, ","))
+		if continuationToken != "" {
+			queryParams.Add("continuationToken", continuationToken)
+		}
+
+		var respObj GetGroupsWithRolesResponse
+		err := client.doJSON("GET", "https://api.fabric.microsoft.com/v1/workspaces?"+queryParams.Encode(), nil, &respObj)
+		if err != nil {
+			return nil, err
+		}
+
+		groups.Value = append(groups.Value, respObj.Value...)
+		if respObj.ContinuationToken == "" {
+			return &groups, nil
+		}
+		continuationToken = respObj.ContinuationToken
+	}
+}
+
 // DeleteGroup deletes a workspace
 func (client *Client) DeleteGroup(groupID string) error {
 	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s", url.PathEscape(groupID))
@@ -10,6 +10,39 @@ type RebindReportInGroupRequest struct {
 	DatasetID string `json:"datasetId"`
 }
 
+// UpdateReportNameInGroupRequest represents the request to rename a report
+type UpdateReportNameInGroupRequest struct {
+	DisplayName string `json:"displayName"`
+}
+
+// CloneReportInGroupRequest represents the request for the CloneReportInGroup API
+type CloneReportInGroupRequest struct {
+	Name              string `json:"name"`
+	TargetModelID     string `json:"targetModelId,omitempty"`
+	TargetWorkspaceID string `json:"targetWorkspaceId,omitempty"`
+}
+
+// CloneReportInGroupResponse represents the report created by the CloneReportInGroup API
+type CloneReportInGroupResponse struct {
+	ID        string
+	Name      string
+	DatasetID string
+	WebURL    string
+	EmbedURL  string
+}
+
+// UpdateReportContentInGroupRequest represents the request for the UpdateReportContentInGroup API
+type UpdateReportContentInGroupRequest struct {
+	SourceReport UpdateReportContentInGroupRequestSourceReport `json:"sourceReport"`
+	SourceType   string                                        `json:"sourceType"`
+}
+
+// UpdateReportContentInGroupRequestSourceReport represents the report to copy content from
+type UpdateReportContentInGroupRequestSourceReport struct {
+	SourceReportID    string `json:"sourceReportId"`
+	SourceWorkspaceID string `json:"sourceWorkspaceId"`
+}
+
 // GetReportsInGroupResponse represents the details when getting a report in a group.
 type GetReportsInGroupResponse struct {
 	Value []GetReportsInGroupResponseItem
@@ -71,6 +104,27 @@ func (client *Client) RebindReportInGroup(groupID string, reportID string, reque
 	return err
 }
 
+// CloneReportInGroup clones the specified report from the specified group.
+// The clone can be created in another workspace and bound to another dataset, otherwise it is bound to the dataset of the source report
+func (client *Client) CloneReportInGroup(groupID string, reportID string, request CloneReportInGroupRequest) (*CloneReportInGroupResponse, error) {
+
+	var respObj CloneReportInGroupResponse
+	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/reports/%s/Clone", url.PathEscape(groupID), url.PathEscape(reportID))
+	err := client.doJSON("POST", url, request, &respObj)
+
+	return &respObj, err
+}
+
+// UpdateReportContentInGroup replaces the content of the specified report with the content of another report, keeping its ID.
+func (client *Client) UpdateReportContentInGroup(groupID string, reportID string, request UpdateReportContentInGroupRequest) (*GetReportInGroupResponse, error) {
+
+	var respObj GetReportInGroupResponse
+	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/reports/%s/UpdateReportContent", url.PathEscape(groupID), url.PathEscape(reportID))
+	err := client.doJSON("POST", url, request, &respObj)
+
+	return &respObj, err
+}
+
 // TakeOverReportInGroup takes over a report that exists within a group.
 func (client *Client) TakeOverReportInGroup(groupID string, reportID string) error {
 	
@@ -79,3 +133,13 @@ func (client *Client) TakeOverReportInGroup(groupID string, reportID string) err
 
 	return err
 }
+
+// UpdateReportNameInGroup renames a report that exists within a group, keeping its ID.
+// The Power BI API has no rename endpoint, so this uses the Fabric update report API.
+func (client *Client) UpdateReportNameInGroup(groupID string, reportID string, request UpdateReportNameInGroupRequest) error {
+
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/reports/%s", url.PathEscape(groupID), url.PathEscape(reportID))
+	err := client.doJSON("PATCH", url, request, nil)
+
+	return err
+}